@@ -1,30 +1,72 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"log"
+	"os"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/vmware/terraform-provider-wavefront/wavefront"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := export(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	var debugMode bool
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug:        debugMode,
-		ProviderAddr: "vmware/wavefront",
-		ProviderFunc: func() *schema.Provider {
-			return wavefront.Provider()
-		},
-	}
-
 	logFlags := log.Flags()
 	logFlags = logFlags &^ (log.Ldate | log.Ltime)
 	log.SetFlags(logFlags)
-	plugin.Serve(opts)
+
+	serverFactory, err := wavefront.ProtoV6ProviderServerFactory(context.Background(), wavefront.Provider())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/vmware/wavefront", serverFactory, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// export runs the export command, which writes the resources of a tenant to
+// .tf files with import blocks. The credentials are configured as for the
+// provider block, with environment variables or a credentials file profile.
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	dir := flags.String("dir", ".", "directory to write the .tf files to")
+	types := flags.String("types", "", "comma separated resource types to export, default "+
+		strings.Join(wavefront.ExportTypes(), ","))
+	address := flags.String("address", "", "address of the Wavefront tenant, default $WAVEFRONT_ADDRESS")
+	profile := flags.String("profile", "", "profile of the credentials file, default $WAVEFRONT_PROFILE")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	options := wavefront.ExportOptions{Dir: *dir, Provider: map[string]interface{}{}}
+	if *types != "" {
+		options.Types = strings.Split(*types, ",")
+	}
+	if *address != "" {
+		options.Provider["address"] = *address
+	}
+	if *profile != "" {
+		options.Provider["profile"] = *profile
+	}
+	return wavefront.Export(context.Background(), options)
 }
@@ -2,11 +2,16 @@ package wavefront
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/wql"
 )
 
 func suppressCase(_, old, new string, _ *schema.ResourceData) bool {
@@ -59,14 +64,6 @@ func decodeAccessControlList(d *schema.ResourceData) (canView, canModify []strin
 	return canView, canModify
 }
 
-// Decodes the tags from the state and returns a []string of tags
-func decodeTags(d *schema.ResourceData) (tags []string) {
-	for _, tag := range d.Get("tags").(*schema.Set).List() {
-		tags = append(tags, tag.(string))
-	}
-	return tags
-}
-
 // Decodes a TypeList of []interface{} to []string
 func decodeTypeListToString(d *schema.ResourceData, field string) []string {
 	var decoded []string
@@ -89,3 +86,104 @@ func decodeTypeMapToStringMap(d *schema.ResourceData, field string) map[string]s
 	}
 	return decoded
 }
+
+// attributeError is an error about the value of the attribute at path.
+// diagFromErr reports it against that attribute.
+type attributeError struct {
+	path cty.Path
+	err  error
+}
+
+func (e *attributeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attributeError) Unwrap() error {
+	return e.err
+}
+
+// newAttributeError returns an attributeError for the top-level attribute key.
+func newAttributeError(key string, format string, a ...interface{}) error {
+	return &attributeError{path: cty.GetAttrPath(key), err: fmt.Errorf(format, a...)}
+}
+
+// diagFromErr converts err to diagnostics like diag.FromErr, except that an
+// attributeError is reported against its attribute.
+func diagFromErr(err error) diag.Diagnostics {
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		return diag.Diagnostics{
+			{
+				Severity:      diag.Error,
+				Summary:       err.Error(),
+				AttributePath: attrErr.path,
+			},
+		}
+	}
+	return diag.FromErr(err)
+}
+
+// validateQuery validates a Wavefront query offline, so that syntax errors
+// fail the plan rather than the apply. Calls of unknown functions and calls
+// with too few arguments are only warnings, as Wavefront may have functions
+// the parser doesn't know of.
+func validateQuery(v interface{}, path cty.Path) diag.Diagnostics {
+	query, ok := v.(string)
+	if !ok || strings.TrimSpace(query) == "" {
+		return nil
+	}
+	warnings, err := wql.Validate(query)
+	var syntaxErr *wql.Error
+	if errors.As(err, &syntaxErr) {
+		return diag.Diagnostics{queryDiagnostic(diag.Error, "invalid Wavefront query", query, syntaxErr, path)}
+	}
+	var diags diag.Diagnostics
+	for _, warning := range warnings {
+		diags = append(diags, queryDiagnostic(diag.Warning, "possibly invalid Wavefront query", query, warning, path))
+	}
+	return diags
+}
+
+// validateQueries validates the Wavefront queries of a map, such as the
+// conditions of a threshold alert.
+func validateQueries(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	queries, _ := v.(map[string]interface{})
+	for key, query := range queries {
+		diags = append(diags, validateQuery(query, path.IndexString(key))...)
+	}
+	return diags
+}
+
+// queryDiagnostic returns a diagnostic for the error at a position of query,
+// quoting the line of the error with a caret under its column.
+func queryDiagnostic(severity diag.Severity, summary, query string, err *wql.Error, path cty.Path) diag.Diagnostic {
+	line := strings.Split(query, "\n")[err.Line-1]
+	caret := strings.Repeat(" ", err.Column-1) + "^"
+	return diag.Diagnostic{
+		Severity:      severity,
+		Summary:       summary,
+		Detail:        fmt.Sprintf("%s\n\n  %s\n  %s", err, line, caret),
+		AttributePath: path,
+	}
+}
+
+// defaultTimeout bounds each operation on a resource or data source, so that a
+// stuck call to the Wavefront API doesn't hang Terraform. It can be changed in
+// the timeouts block of every resource and data source.
+const defaultTimeout = 5 * time.Minute
+
+func resourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(defaultTimeout),
+		Read:   schema.DefaultTimeout(defaultTimeout),
+		Update: schema.DefaultTimeout(defaultTimeout),
+		Delete: schema.DefaultTimeout(defaultTimeout),
+	}
+}
+
+func dataSourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Read: schema.DefaultTimeout(defaultTimeout),
+	}
+}
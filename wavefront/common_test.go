@@ -0,0 +1,48 @@
+package wavefront
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagFromErr(t *testing.T) {
+	diags := diagFromErr(errors.New("plain"))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "plain", diags[0].Summary)
+	assert.Nil(t, diags[0].AttributePath)
+
+	diags = diagFromErr(fmt.Errorf("wrapped: %w", newAttributeError(conditionKey, "condition must be supplied")))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, "wrapped: condition must be supplied", diags[0].Summary)
+	assert.Equal(t, cty.GetAttrPath(conditionKey), diags[0].AttributePath)
+
+	assert.Nil(t, diagFromErr(nil))
+}
+
+func TestValidateQuery(t *testing.T) {
+	path := cty.GetAttrPath(conditionKey)
+	assert.Empty(t, validateQuery("ts(cpu.usage) > 90", path))
+	assert.Empty(t, validateQuery("", path))
+
+	diags := validateQuery("ts(cpu.usage)\n  > > 90", path)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Error, diags[0].Severity)
+	assert.Equal(t, "invalid Wavefront query", diags[0].Summary)
+	assert.Equal(t, "line 2, column 5: unexpected \">\", expected an expression\n\n    > > 90\n      ^", diags[0].Detail)
+	assert.Equal(t, path, diags[0].AttributePath)
+
+	diags = validateQuery("mvag(5m, ts(cpu.usage))", path)
+	assert.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+	assert.Contains(t, diags[0].Detail, "unknown function mvag, did you mean mavg?")
+
+	diags = validateQueries(map[string]interface{}{"severe": "ts(cpu.usage) >", "warn": "ts(cpu.usage) > 80"},
+		cty.GetAttrPath(conditionsKey))
+	assert.Len(t, diags, 1)
+	assert.Equal(t, cty.GetAttrPath(conditionsKey).IndexString("severe"), diags[0].AttributePath)
+}
@@ -0,0 +1,145 @@
+package wavefront
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultProfile is the profile used when none is configured. Unlike a
+// configured profile, it doesn't need to exist.
+const defaultProfile = "default"
+
+// credentialsProfile is a named profile of the shared credentials file.
+type credentialsProfile struct {
+	Address      string `yaml:"address" ini:"address"`
+	Token        string `yaml:"token" ini:"token"`
+	CSPAPIToken  string `yaml:"csp_api_token" ini:"csp_api_token"`
+	CSPAppID     string `yaml:"csp_app_id" ini:"csp_app_id"`
+	CSPAppSecret string `yaml:"csp_app_secret" ini:"csp_app_secret"`
+	CSPOrgID     string `yaml:"csp_org_id" ini:"csp_org_id"`
+}
+
+// providerCredentials are the address and credentials the provider connects
+// with. Exactly one of Token, CSP.APIToken or CSP.AppID is set once resolved.
+type providerCredentials struct {
+	Address string
+	Token   string
+	CSP     cspCredentials
+}
+
+// hasCredentials returns whether any way of authenticating is set.
+func (c *providerCredentials) hasCredentials() bool {
+	return c.Token != "" || c.CSP.APIToken != "" || c.CSP.AppID != "" || c.CSP.AppSecret != ""
+}
+
+// mergeProfile fills in the settings missing from c with the ones of profile.
+// Credentials are taken as a whole, so that a token set in the provider block
+// or the environment isn't mixed with CSP credentials from the profile.
+func (c *providerCredentials) mergeProfile(profile *credentialsProfile) {
+	if c.Address == "" {
+		c.Address = profile.Address
+	}
+	if !c.hasCredentials() {
+		c.Token = profile.Token
+		c.CSP.APIToken = profile.CSPAPIToken
+		c.CSP.AppID = profile.CSPAppID
+		c.CSP.AppSecret = profile.CSPAppSecret
+	}
+	if c.CSP.OrgID == "" {
+		c.CSP.OrgID = profile.CSPOrgID
+	}
+}
+
+// defaultCredentialsFile returns the path of ~/.wavefront/credentials.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".wavefront", "credentials")
+}
+
+// loadCredentialsProfile returns the profile called name of the credentials
+// file at path. A missing file or profile is an error only if the profile was
+// configured, otherwise it returns nil.
+func loadCredentialsProfile(path, name string) (*credentialsProfile, error) {
+	configured := name != ""
+	if !configured {
+		name = defaultProfile
+	}
+	if path == "" {
+		path = defaultCredentialsFile()
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !configured {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file %s, %s", path, err)
+	}
+	profiles, err := parseCredentialsFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse credentials file %s, %s", path, err)
+	}
+	profile, ok := profiles[name]
+	if !ok {
+		if !configured {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("profile %q not found in credentials file %s", name, path)
+	}
+	return profile, nil
+}
+
+// parseCredentialsFile parses a credentials file, in the INI format when it
+// starts with a [section], in YAML otherwise.
+func parseCredentialsFile(data []byte) (map[string]*credentialsProfile, error) {
+	if isINI(data) {
+		return parseINICredentials(data)
+	}
+	profiles := make(map[string]*credentialsProfile)
+	if err := yaml.Unmarshal(data, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+func isINI(data []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		return strings.HasPrefix(line, "[")
+	}
+	return false
+}
+
+func parseINICredentials(data []byte) (map[string]*credentialsProfile, error) {
+	file, err := ini.Load(data)
+	if err != nil {
+		return nil, err
+	}
+	profiles := make(map[string]*credentialsProfile)
+	for _, section := range file.Sections() {
+		if section.Name() == ini.DefaultSection {
+			continue
+		}
+		profile := &credentialsProfile{}
+		if err := section.MapTo(profile); err != nil {
+			return nil, fmt.Errorf("profile %q, %s", section.Name(), err)
+		}
+		profiles[section.Name()] = profile
+	}
+	return profiles, nil
+}
@@ -0,0 +1,207 @@
+package wavefront
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testINICredentials = `
+# Wavefront tenants
+[default]
+address = default.wavefront.com
+token   = default-token
+
+[payments]
+address       = payments.wavefront.com
+csp_api_token = payments-csp-token
+
+[search]
+address        = search.wavefront.com
+csp_app_id     = search-app
+csp_app_secret = search-secret
+csp_org_id     = search-org
+`
+
+const testYAMLCredentials = `
+# Wavefront tenants
+default:
+  address: default.wavefront.com
+  token: default-token
+payments:
+  address: payments.wavefront.com
+  csp_api_token: payments-csp-token
+search:
+  address: search.wavefront.com
+  csp_app_id: search-app
+  csp_app_secret: search-secret
+  csp_org_id: search-org
+`
+
+var testProviderEnv = []string{
+	"WAVEFRONT_ADDRESS", "WAVEFRONT_TOKEN", "WAVEFRONT_PROFILE", "WAVEFRONT_CREDENTIALS_FILE",
+	"WAVEFRONT_CSP_API_TOKEN", "WAVEFRONT_CSP_APP_ID", "WAVEFRONT_CSP_APP_SECRET", "WAVEFRONT_CSP_ORG_ID",
+	"WAVEFRONT_CSP_BASE_URL",
+}
+
+// testResolveProviderCredentials resolves the credentials of config with the
+// environment variables env, isolated from the environment and the
+// credentials file of the user running the test.
+func testResolveProviderCredentials(t *testing.T, env map[string]string, config map[string]interface{}) (*providerCredentials, error) {
+	for _, name := range testProviderEnv {
+		t.Setenv(name, env[name])
+	}
+	t.Setenv("HOME", t.TempDir())
+	d := schema.TestResourceDataRaw(t, Provider().Schema, config)
+	return resolveProviderCredentials(d)
+}
+
+func writeTestCredentials(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestParseCredentialsFile(t *testing.T) {
+	expected := map[string]*credentialsProfile{
+		"default":  {Address: "default.wavefront.com", Token: "default-token"},
+		"payments": {Address: "payments.wavefront.com", CSPAPIToken: "payments-csp-token"},
+		"search": {
+			Address:      "search.wavefront.com",
+			CSPAppID:     "search-app",
+			CSPAppSecret: "search-secret",
+			CSPOrgID:     "search-org",
+		},
+	}
+	for format, content := range map[string]string{"ini": testINICredentials, "yaml": testYAMLCredentials} {
+		profiles, err := parseCredentialsFile([]byte(content))
+		require.NoError(t, err, format)
+		assert.Equal(t, expected, profiles, format)
+	}
+
+	_, err := parseCredentialsFile([]byte("default: [unterminated"))
+	assert.Error(t, err)
+}
+
+func TestLoadCredentialsProfile(t *testing.T) {
+	path := writeTestCredentials(t, testINICredentials)
+
+	profile, err := loadCredentialsProfile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "default.wavefront.com", profile.Address)
+
+	profile, err = loadCredentialsProfile(path, "payments")
+	require.NoError(t, err)
+	assert.Equal(t, "payments.wavefront.com", profile.Address)
+
+	_, err = loadCredentialsProfile(path, "missing")
+	assert.ErrorContains(t, err, `profile "missing" not found`)
+
+	missing := filepath.Join(t.TempDir(), "credentials")
+	profile, err = loadCredentialsProfile(missing, "")
+	assert.NoError(t, err, "the default profile is optional")
+	assert.Nil(t, profile)
+
+	_, err = loadCredentialsProfile(missing, "payments")
+	assert.ErrorContains(t, err, "failed to read credentials file")
+}
+
+func TestResolveProviderCredentials_Precedence(t *testing.T) {
+	path := writeTestCredentials(t, testYAMLCredentials)
+
+	cases := []struct {
+		name     string
+		env      map[string]string
+		config   map[string]interface{}
+		expected providerCredentials
+	}{
+		{
+			name:   "default profile",
+			config: map[string]interface{}{"credentials_file": path},
+			expected: providerCredentials{
+				Address: "default.wavefront.com",
+				Token:   "default-token",
+			},
+		},
+		{
+			name:   "profile argument",
+			env:    map[string]string{"WAVEFRONT_PROFILE": "search"},
+			config: map[string]interface{}{"credentials_file": path, "profile": "payments"},
+			expected: providerCredentials{
+				Address: "payments.wavefront.com",
+				CSP:     cspCredentials{APIToken: "payments-csp-token"},
+			},
+		},
+		{
+			name:   "profile environment variable",
+			env:    map[string]string{"WAVEFRONT_PROFILE": "search"},
+			config: map[string]interface{}{"credentials_file": path},
+			expected: providerCredentials{
+				Address: "search.wavefront.com",
+				CSP:     cspCredentials{AppID: "search-app", AppSecret: "search-secret", OrgID: "search-org"},
+			},
+		},
+		{
+			name:   "credentials file environment variable",
+			env:    map[string]string{"WAVEFRONT_CREDENTIALS_FILE": path},
+			config: map[string]interface{}{},
+			expected: providerCredentials{
+				Address: "default.wavefront.com",
+				Token:   "default-token",
+			},
+		},
+		{
+			name: "environment variables over the profile",
+			env:  map[string]string{"WAVEFRONT_ADDRESS": "env.wavefront.com", "WAVEFRONT_TOKEN": "env-token"},
+			config: map[string]interface{}{
+				"credentials_file": path,
+				"profile":          "payments",
+			},
+			expected: providerCredentials{
+				Address: "env.wavefront.com",
+				Token:   "env-token",
+			},
+		},
+		{
+			name: "arguments over environment variables",
+			env:  map[string]string{"WAVEFRONT_ADDRESS": "env.wavefront.com", "WAVEFRONT_TOKEN": "env-token"},
+			config: map[string]interface{}{
+				"address": "config.wavefront.com",
+				"token":   "config-token",
+			},
+			expected: providerCredentials{
+				Address: "config.wavefront.com",
+				Token:   "config-token",
+			},
+		},
+		{
+			name: "credentials are not mixed with the profile's",
+			config: map[string]interface{}{
+				"credentials_file": path,
+				"profile":          "payments",
+				"token":            "config-token",
+			},
+			expected: providerCredentials{
+				Address: "payments.wavefront.com",
+				Token:   "config-token",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			credentials, err := testResolveProviderCredentials(t, c.env, c.config)
+			require.NoError(t, err)
+			c.expected.CSP.BaseURL = defaultCSPBaseURL
+			assert.Equal(t, c.expected, *credentials)
+		})
+	}
+}
+
+func TestResolveProviderCredentials_MissingAddress(t *testing.T) {
+	_, err := testResolveProviderCredentials(t, nil, map[string]interface{}{"token": "wf-token"})
+	assert.ErrorContains(t, err, "address must be set")
+}
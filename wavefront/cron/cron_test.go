@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	for expr, message := range map[string]string{
+		"":              "expected 5 fields, got 0",
+		"0 2 * *":       "expected 5 fields, got 4",
+		"60 * * * *":    `invalid minute "60", expected 0 to 59`,
+		"0 24 * * *":    `invalid hour "24", expected 0 to 23`,
+		"0 0 0 * *":     `invalid day of month "0", expected 1 to 31`,
+		"0 0 * foo *":   `invalid month "foo", expected 1 to 12`,
+		"0 0 * * 8":     `invalid day of week "8", expected 0 to 7`,
+		"*/0 * * * *":   `invalid step "0" of the minute`,
+		"0 5-1 * * *":   `invalid range "5-1" of the hour`,
+		"0 0 * * mon-x": `invalid day of week "x", expected 0 to 7`,
+	} {
+		_, err := Parse(expr)
+		assert.ErrorContains(t, err, message, expr)
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Saturday
+	from := time.Date(2024, time.March, 2, 10, 17, 30, 0, time.UTC)
+	for expr, expected := range map[string]time.Time{
+		"* * * * *":          time.Date(2024, time.March, 2, 10, 18, 0, 0, time.UTC),
+		"*/15 * * * *":       time.Date(2024, time.March, 2, 10, 30, 0, 0, time.UTC),
+		"0 2 * * sun":        time.Date(2024, time.March, 3, 2, 0, 0, 0, time.UTC),
+		"0 2 * * 7":          time.Date(2024, time.March, 3, 2, 0, 0, 0, time.UTC),
+		"30 22 * * MON-FRI":  time.Date(2024, time.March, 4, 22, 30, 0, 0, time.UTC),
+		"0 0 1 * *":          time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 29 feb *":       time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		"0 9 15 * mon":       time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC),
+		"0 12,18 * * *":      time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC),
+		"5/20 10 * * *":      time.Date(2024, time.March, 2, 10, 25, 0, 0, time.UTC),
+		"@weekly":            time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC),
+		"@hourly":            time.Date(2024, time.March, 2, 11, 0, 0, 0, time.UTC),
+		"0 0 1 jan,jul *":    time.Date(2024, time.July, 1, 0, 0, 0, 0, time.UTC),
+		"0 0 30 2 *":         {},
+		"17 10 2 3 *":        time.Date(2025, time.March, 2, 10, 17, 0, 0, time.UTC),
+		"0-10/5 11-12 * * *": time.Date(2024, time.March, 2, 11, 0, 0, 0, time.UTC),
+	} {
+		schedule, err := Parse(expr)
+		require.NoError(t, err, expr)
+		assert.Equal(t, expected, schedule.Next(from), expr)
+	}
+}
+
+func TestSchedule_NextInLocation(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	schedule, err := Parse("0 2 * * *")
+	require.NoError(t, err)
+
+	next := schedule.Next(time.Date(2024, time.March, 8, 12, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, time.March, 9, 2, 0, 0, 0, loc), next)
+	assert.Equal(t, time.Date(2024, time.March, 9, 7, 0, 0, 0, time.UTC), next.UTC())
+
+	// 2am doesn't exist on the day the clocks go forward, so it's skipped.
+	next = schedule.Next(next)
+	assert.Equal(t, time.Date(2024, time.March, 11, 2, 0, 0, 0, loc), next)
+	assert.Equal(t, time.Date(2024, time.March, 11, 6, 0, 0, 0, time.UTC), next.UTC())
+
+	// 1am happens twice on the day the clocks go back.
+	schedule, err = Parse("30 1 * * *")
+	require.NoError(t, err)
+	next = schedule.Next(time.Date(2024, time.November, 3, 0, 0, 0, 0, loc))
+	assert.Equal(t, time.Date(2024, time.November, 3, 5, 30, 0, 0, time.UTC), next.UTC())
+}
@@ -0,0 +1,167 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCSPBaseURL = "https://console.cloud.vmware.com"
+
+	// The VMware Cloud Services endpoints exchanging an API token, or the
+	// credentials of a server to server app, for an access token.
+	cspAPITokenPath = "/csp/gateway/am/api/auth/api-tokens/authorize"
+	cspOAuthPath    = "/csp/gateway/am/api/auth/authorize"
+
+	// cspRefreshMargin is how long before it expires an access token is
+	// replaced, so that it doesn't expire during a request.
+	cspRefreshMargin = time.Minute
+)
+
+// cspCredentials are the credentials exchanged for access tokens. Either
+// APIToken or AppID and AppSecret are set.
+type cspCredentials struct {
+	BaseURL   string
+	APIToken  string
+	AppID     string
+	AppSecret string
+	OrgID     string
+}
+
+type cspTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// cspTokenSource hands out VMware Cloud Services access tokens, exchanging
+// its credentials for a new one when the current one is about to expire.
+type cspTokenSource struct {
+	credentials cspCredentials
+	client      *http.Client
+	now         func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	refreshAt   time.Time
+}
+
+func newCSPTokenSource(credentials cspCredentials, transport http.RoundTripper) *cspTokenSource {
+	return &cspTokenSource{
+		credentials: credentials,
+		client:      &http.Client{Transport: transport},
+		now:         time.Now,
+	}
+}
+
+// token returns a valid access token.
+func (s *cspTokenSource) token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && s.now().Before(s.refreshAt) {
+		return s.accessToken, nil
+	}
+	resp, err := s.exchange(ctx)
+	if err != nil {
+		return "", err
+	}
+	lifetime := time.Duration(resp.ExpiresIn) * time.Second
+	margin := cspRefreshMargin
+	if margin > lifetime/2 {
+		margin = lifetime / 2
+	}
+	s.accessToken = resp.AccessToken
+	s.refreshAt = s.now().Add(lifetime - margin)
+	log.Printf("[DEBUG] obtained a VMware Cloud Services access token valid for %s", lifetime)
+	return s.accessToken, nil
+}
+
+// invalidate discards the current access token, e.g. after it was rejected.
+func (s *cspTokenSource) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessToken = ""
+}
+
+func (s *cspTokenSource) exchange(ctx context.Context) (*cspTokenResponse, error) {
+	form := url.Values{}
+	path := cspOAuthPath
+	if s.credentials.APIToken != "" {
+		path = cspAPITokenPath
+		form.Set("api_token", s.credentials.APIToken)
+	} else {
+		form.Set("grant_type", "client_credentials")
+		if s.credentials.OrgID != "" {
+			form.Set("orgId", s.credentials.OrgID)
+		}
+	}
+
+	endpoint := strings.TrimSuffix(s.credentials.BaseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if s.credentials.APIToken == "" {
+		req.SetBasicAuth(s.credentials.AppID, s.credentials.AppSecret)
+	}
+
+	httpResp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with VMware Cloud Services, %s", err)
+	}
+	defer httpResp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(httpResp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate with VMware Cloud Services, %s", err)
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to authenticate with VMware Cloud Services, server returned %s\n%s",
+			httpResp.Status, body)
+	}
+	var resp cspTokenResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse VMware Cloud Services access token, %s", err)
+	}
+	if resp.AccessToken == "" {
+		return nil, fmt.Errorf("VMware Cloud Services returned no access token")
+	}
+	return &resp, nil
+}
+
+// cspAuthTransport authorizes requests with an access token from source,
+// replacing the static token set by the client library.
+type cspAuthTransport struct {
+	base   http.RoundTripper
+	source *cspTokenSource
+}
+
+func newCSPAuthTransport(base http.RoundTripper, source *cspTokenSource) *cspAuthTransport {
+	return &cspAuthTransport{base: base, source: source}
+}
+
+func (t *cspAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.source.token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	authorized := req.Clone(req.Context())
+	authorized.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := t.base.RoundTrip(authorized)
+	if err == nil && resp.StatusCode == http.StatusUnauthorized {
+		// The token may have been revoked, get a new one next time.
+		t.source.invalidate()
+	}
+	return resp, err
+}
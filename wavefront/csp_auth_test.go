@@ -0,0 +1,211 @@
+package wavefront
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCSPServer is a fake VMware Cloud Services issuing access-1, access-2,
+// ... valid for expiresIn seconds. check verifies each token request.
+func testCSPServer(t *testing.T, expiresIn int, check func(r *http.Request)) (*httptest.Server, *int32) {
+	var issued int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		check(r)
+		n := atomic.AddInt32(&issued, 1)
+		_, _ = fmt.Fprintf(w, `{"access_token":"access-%d","expires_in":%d,"token_type":"bearer"}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+	return server, &issued
+}
+
+// testWavefrontServer is a fake Wavefront API recording the Authorization
+// header of each request. It rejects the requests authorized with revoked.
+func testWavefrontServer(t *testing.T, revoked string) (*httptest.Server, *[]string) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		authorizations = append(authorizations, authorization)
+		if authorization == "Bearer "+revoked {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		alertHandler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, &authorizations
+}
+
+func testCSPClient(t *testing.T, server *httptest.Server, source *cspTokenSource) *wavefront.Client {
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL})
+	require.NoError(t, err)
+	httpClient := getHTTPClient(client)
+	httpClient.Transport = newCSPAuthTransport(httpClient.Transport, source)
+	return client
+}
+
+func getTestAlert(client *wavefront.Client) error {
+	id := "1234"
+	return client.Alerts().Get(&wavefront.Alert{ID: &id})
+}
+
+func TestCSPAuth_APIToken(t *testing.T) {
+	csp, issued := testCSPServer(t, 1800, func(r *http.Request) {
+		assert.Equal(t, cspAPITokenPath, r.URL.Path)
+		assert.Equal(t, "my-api-token", r.PostForm.Get("api_token"))
+	})
+	server, authorizations := testWavefrontServer(t, "")
+
+	source := newCSPTokenSource(cspCredentials{BaseURL: csp.URL, APIToken: "my-api-token"}, http.DefaultTransport)
+	client := testCSPClient(t, server, source)
+	require.NoError(t, getTestAlert(client))
+	require.NoError(t, getTestAlert(client))
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(issued), "the access token must be reused")
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-1"}, *authorizations)
+}
+
+func TestCSPAuth_ServerToServerApp(t *testing.T) {
+	csp, _ := testCSPServer(t, 1800, func(r *http.Request) {
+		assert.Equal(t, cspOAuthPath, r.URL.Path)
+		id, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "my-app", id)
+		assert.Equal(t, "my-secret", secret)
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "my-org", r.PostForm.Get("orgId"))
+	})
+	server, authorizations := testWavefrontServer(t, "")
+
+	source := newCSPTokenSource(cspCredentials{
+		BaseURL:   csp.URL,
+		AppID:     "my-app",
+		AppSecret: "my-secret",
+		OrgID:     "my-org",
+	}, http.DefaultTransport)
+	require.NoError(t, getTestAlert(testCSPClient(t, server, source)))
+	assert.Equal(t, []string{"Bearer access-1"}, *authorizations)
+}
+
+func TestCSPAuth_RefreshesBeforeExpiry(t *testing.T) {
+	csp, issued := testCSPServer(t, 600, func(*http.Request) {})
+	server, authorizations := testWavefrontServer(t, "")
+
+	now := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	source := newCSPTokenSource(cspCredentials{BaseURL: csp.URL, APIToken: "my-api-token"}, http.DefaultTransport)
+	source.now = func() time.Time { return now }
+	client := testCSPClient(t, server, source)
+
+	require.NoError(t, getTestAlert(client))
+	now = now.Add(8 * time.Minute)
+	require.NoError(t, getTestAlert(client))
+	// Less than cspRefreshMargin before expiry.
+	now = now.Add(90 * time.Second)
+	require.NoError(t, getTestAlert(client))
+
+	assert.EqualValues(t, 2, atomic.LoadInt32(issued))
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-1", "Bearer access-2"}, *authorizations)
+}
+
+func TestCSPAuth_ReplacesRejectedToken(t *testing.T) {
+	csp, issued := testCSPServer(t, 1800, func(*http.Request) {})
+	server, authorizations := testWavefrontServer(t, "access-1")
+
+	source := newCSPTokenSource(cspCredentials{BaseURL: csp.URL, APIToken: "my-api-token"}, http.DefaultTransport)
+	client := testCSPClient(t, server, source)
+	assert.Error(t, getTestAlert(client))
+	require.NoError(t, getTestAlert(client))
+
+	assert.EqualValues(t, 2, atomic.LoadInt32(issued))
+	assert.Equal(t, []string{"Bearer access-1", "Bearer access-2"}, *authorizations)
+}
+
+func TestCSPAuth_ReportsFailedExchange(t *testing.T) {
+	csp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid_grant"}`))
+	}))
+	defer csp.Close()
+	server, authorizations := testWavefrontServer(t, "")
+
+	source := newCSPTokenSource(cspCredentials{BaseURL: csp.URL, APIToken: "my-api-token"}, http.DefaultTransport)
+	err := getTestAlert(testCSPClient(t, server, source))
+	assert.ErrorContains(t, err, "failed to authenticate with VMware Cloud Services")
+	assert.ErrorContains(t, err, "invalid_grant")
+	assert.Empty(t, *authorizations)
+}
+
+func TestResolveProviderCredentials_AuthModes(t *testing.T) {
+	cases := []struct {
+		name     string
+		config   map[string]interface{}
+		expected cspCredentials
+		err      string
+	}{
+		{
+			name:     "token",
+			config:   map[string]interface{}{"token": "wf-token"},
+			expected: cspCredentials{BaseURL: defaultCSPBaseURL},
+		},
+		{
+			name:   "api token",
+			config: map[string]interface{}{"csp_api_token": "csp-token"},
+			expected: cspCredentials{
+				BaseURL:  defaultCSPBaseURL,
+				APIToken: "csp-token",
+			},
+		},
+		{
+			name: "server to server app",
+			config: map[string]interface{}{
+				"csp_app_id":     "my-app",
+				"csp_app_secret": "my-secret",
+				"csp_org_id":     "my-org",
+				"csp_base_url":   "https://csp.example.com",
+			},
+			expected: cspCredentials{
+				BaseURL:   "https://csp.example.com",
+				AppID:     "my-app",
+				AppSecret: "my-secret",
+				OrgID:     "my-org",
+			},
+		},
+		{
+			name: "nothing",
+			err:  "one of token, csp_api_token or csp_app_id and csp_app_secret must be set",
+		},
+		{
+			name:   "token and api token",
+			config: map[string]interface{}{"token": "wf-token", "csp_api_token": "csp-token"},
+			err:    "only one of",
+		},
+		{
+			name:   "app without secret",
+			config: map[string]interface{}{"csp_app_id": "my-app"},
+			err:    "csp_app_id and csp_app_secret must be set together",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := map[string]interface{}{"address": "cluster.wavefront.com"}
+			for k, v := range c.config {
+				config[k] = v
+			}
+			credentials, err := testResolveProviderCredentials(t, nil, config)
+			if c.err != "" {
+				assert.ErrorContains(t, err, c.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected, credentials.CSP)
+		})
+	}
+}
@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceAlert() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceAlertRead,
-		Schema: dataSourceAlertSchema(),
+		ReadContext: dataSourceAlertRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceAlertSchema(),
 	}
 }
 
@@ -198,22 +201,22 @@ func sourceLabelSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceAlertRead(d *schema.ResourceData, m interface{}) error {
-	alertClient := m.(*wavefrontClient).client.Alerts()
+func dataSourceAlertRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	alertClient := m.(*wavefrontClient).withContext(ctx).Alerts()
 	id, ok := d.GetOk(idKey)
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	alert := wavefront.Alert{ID: &idStr}
 	if err := alertClient.Get(&alert); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setAlertAttributes(d, alert)
+	return diag.FromErr(setAlertAttributes(d, alert))
 }
 
 func setAlertAttributes(d *schema.ResourceData, alert wavefront.Alert) error {
@@ -0,0 +1,53 @@
+package wavefront
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlertSnooze() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlertSnoozeRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			alertIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			snoozedKey: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			untilTimeInSecondsKey: {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAlertSnoozeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	alertID := d.Get(alertIDKey).(string)
+	snooze, err := getAlertSnooze(client, alertID)
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Alert %s. %s", alertID, err)
+	}
+
+	snoozed := snooze.active(time.Now())
+	if err = d.Set(snoozedKey, snoozed); err != nil {
+		return diag.FromErr(err)
+	}
+	var until int64
+	if snoozed {
+		until = snooze.untilTimeInSeconds()
+	}
+	if err = d.Set(untilTimeInSecondsKey, int(until)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(alertID)
+	return nil
+}
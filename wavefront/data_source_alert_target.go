@@ -0,0 +1,153 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAlertTarget() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlertTargetRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceAlertTargetSchema(),
+	}
+}
+
+// dataSourceAlertTargetSchema is the schema of the alert target data source,
+// looked up by its id or its name.
+func dataSourceAlertTargetSchema() map[string]*schema.Schema {
+	s := alertTargetSchema()
+	s[idKey] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{idKey, nameKey},
+	}
+	s[nameKey] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{idKey, nameKey},
+	}
+	return s
+}
+
+// alertTargetSchema is the schema of the alert targets of the data sources.
+func alertTargetSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		idKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		nameKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		descriptionKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"triggers": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"template": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"method": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"recipient": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"route": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"method": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"target": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"filter": {
+						Type:     schema.TypeMap,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"email_subject": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"is_html_content": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		"content_type": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"custom_headers": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"target_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceAlertTargetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var target wavefront.Target
+	if id, ok := d.GetOk(idKey); ok {
+		targetID := id.(string)
+		target.ID = &targetID
+		if err := m.(*wavefrontClient).withContext(ctx).Targets().Get(&target); err != nil {
+			return diag.Errorf("error finding Wavefront Alert Target %s. %s", targetID, err)
+		}
+	} else {
+		name := d.Get(nameKey).(string)
+		item, err := searchOne(ctx, "notificant", "title", name, m)
+		if err != nil {
+			return diag.Errorf("error finding Wavefront Alert Target %q. %s", name, err)
+		}
+		if err = json.Unmarshal(item, &target); err != nil {
+			return diag.Errorf("Response is invalid JSON")
+		}
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	for key, value := range flattenAlertTarget(&target) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// flattenAlertTarget returns the attributes of the alert target in the data
+// sources: those of the resource and its id.
+func flattenAlertTarget(target *wavefront.Target) map[string]interface{} {
+	tfMap := flattenTarget(target)
+	tfMap[idKey] = *target.ID
+	return tfMap
+}
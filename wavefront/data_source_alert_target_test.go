@@ -0,0 +1,94 @@
+package wavefront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertTargetDataSources_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	ctx := context.Background()
+
+	for _, name := range []string{"On-Call Webhook", "SRE Email"} {
+		require.NoError(t, client.Targets().Create(&wavefront.Target{
+			Title:     name,
+			Template:  "{}",
+			Method:    "WEBHOOK",
+			Recipient: "https://example.com/hook",
+			Triggers:  []string{"ALERT_OPENED"},
+			Routes:    []wavefront.AlertRoute{{Method: "WEBHOOK", Target: "https://example.com/prod", Filter: "env prod"}},
+		}))
+	}
+
+	alertTarget := dataSourceAlertTarget()
+	d := schema.TestResourceDataRaw(t, alertTarget.Schema, map[string]interface{}{nameKey: "SRE Email"})
+	diags := alertTarget.ReadContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	targetID := d.Get(idKey).(string)
+	assert.NotEmpty(t, targetID)
+	assert.Equal(t, "target:"+targetID, d.Get("target_id"))
+	assert.Equal(t, "WEBHOOK", d.Get("method"))
+	assert.Equal(t, "prod", d.Get("route.0.filter.value"))
+
+	byID := schema.TestResourceDataRaw(t, alertTarget.Schema, map[string]interface{}{idKey: targetID})
+	diags = alertTarget.ReadContext(ctx, byID, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "SRE Email", byID.Get(nameKey))
+
+	missing := schema.TestResourceDataRaw(t, alertTarget.Schema, map[string]interface{}{nameKey: "Nobody"})
+	diags = alertTarget.ReadContext(ctx, missing, provider.Meta())
+	assert.True(t, diags.HasError(), "unknown names must fail")
+
+	alertTargets := dataSourceAlertTargets()
+	all := schema.TestResourceDataRaw(t, alertTargets.Schema, map[string]interface{}{})
+	diags = alertTargets.ReadContext(ctx, all, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, all.Get("alert_targets.#"))
+	assert.Equal(t, "On-Call Webhook", all.Get("alert_targets.0.name"))
+}
+
+func TestAccWavefrontAlertTargetDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertTargetDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.wavefront_alert_target.by_name", "id", "wavefront_alert_target.test_target", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.wavefront_alert_target.by_id", "name", "wavefront_alert_target.test_target", "name"),
+					resource.TestCheckResourceAttr("data.wavefront_alert_target.by_name", "method", "EMAIL"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckWavefrontAlertTargetDataSource = `
+resource "wavefront_alert_target" "test_target" {
+  name        = "Terraform Test Target Data Source"
+  description = "Test target"
+  method      = "EMAIL"
+  recipient   = "test@example.com"
+  template    = "{}"
+  triggers    = ["ALERT_OPENED"]
+}
+
+data "wavefront_alert_target" "by_name" {
+  name = wavefront_alert_target.test_target.name
+}
+
+data "wavefront_alert_target" "by_id" {
+  id = wavefront_alert_target.test_target.id
+}
+`
@@ -0,0 +1,68 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const alertTargetsKey = "alert_targets"
+
+func dataSourceAlertTargets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAlertTargetsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceAlertTargetsSchema(),
+	}
+}
+
+func dataSourceAlertTargetsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		alertTargetsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: alertTargetSchema()},
+		},
+		limitKey: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		offsetKey: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		filterKey: searchFilterSchema(),
+	}
+}
+
+func dataSourceAlertTargetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var allTargets []*wavefront.Target
+
+	limit := d.Get(limitKey).(int)
+	offset := d.Get(offsetKey).(int)
+
+	items, err := searchAll(ctx, limit, offset, "notificant", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allTargets); err != nil {
+		return diag.Errorf("Response is invalid JSON")
+	}
+
+	tfMaps := make([]map[string]interface{}, len(allTargets))
+	for i, target := range allTargets {
+		tfMaps[i] = flattenAlertTarget(target)
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	return diag.FromErr(d.Set(alertTargetsKey, tfMaps))
+}
@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceAlerts() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceAlertsRead,
-		Schema: dataSourceAlertsSchema(),
+		ReadContext: dataSourceAlertsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceAlertsSchema(),
 	}
 }
 
@@ -27,33 +30,38 @@ func dataSourceAlertsSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  0,
 		},
+		filterKey: searchFilterSchema(),
 	}
 
 }
 
-func dataSourceAlertsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceAlertsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var allAlerts []*wavefront.Alert
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "alert", nil, nil, m), &allAlerts); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "alert", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allAlerts); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set(alertsKey, flattenAlerts(allAlerts))
+	return diag.FromErr(d.Set(alertsKey, flattenAlerts(allAlerts)))
 }
 
 func flattenAlerts(alerts []*wavefront.Alert) []map[string]interface{} {
@@ -0,0 +1,348 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	ciServiceKey                 = "service"
+	ciAdditionalTagsKey          = "additional_tags"
+	ciServiceRefreshRateKey      = "service_refresh_rate_in_minutes"
+	ciDisabledKey                = "disabled"
+	ciLastErrorKey               = "last_error"
+	ciLastErrorMsKey             = "last_error_ms"
+	ciLastReceivedDataPointMsKey = "last_received_data_point_ms"
+	ciLastMetricCountKey         = "last_metric_count"
+
+	ciCloudWatchKey       = "cloudwatch"
+	ciCloudTrailKey       = "cloudtrail"
+	ciEc2Key              = "ec2"
+	ciGcpKey              = "gcp"
+	ciGcpBillingKey       = "gcp_billing"
+	ciNewRelicKey         = "newrelic"
+	ciAppDynamicsKey      = "app_dynamics"
+	ciAzureKey            = "azure"
+	ciAzureActivityLogKey = "azure_activity_log"
+	ciDynatraceKey        = "dynatrace"
+	ciSnowflakeKey        = "snowflake"
+	ciVropsKey            = "vrops"
+)
+
+func dataSourceCloudIntegration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudIntegrationRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceCloudIntegrationSchema(),
+	}
+}
+
+// dataSourceCloudIntegrationSchema is the schema of the cloud integration
+// data source, looked up by its id.
+func dataSourceCloudIntegrationSchema() map[string]*schema.Schema {
+	s := cloudIntegrationSchema()
+	s[idKey] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	return s
+}
+
+func computedStringSchema() *schema.Schema {
+	return &schema.Schema{Type: schema.TypeString, Computed: true}
+}
+
+func computedBoolSchema() *schema.Schema {
+	return &schema.Schema{Type: schema.TypeBool, Computed: true}
+}
+
+func computedStringListSchema() *schema.Schema {
+	return &schema.Schema{Type: schema.TypeList, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}}
+}
+
+func computedStringMapSchema() *schema.Schema {
+	return &schema.Schema{Type: schema.TypeMap, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}}
+}
+
+// computedBlockSchema is the schema of the configuration of a service, a list
+// holding a single block when the integration is of the service.
+func computedBlockSchema(attributes map[string]*schema.Schema) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem:     &schema.Resource{Schema: attributes},
+	}
+}
+
+// cloudIntegrationSchema is the schema of the cloud integrations of the data
+// sources. The configuration of each service is in the block named after its
+// resource, without the secrets.
+func cloudIntegrationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		idKey:                        computedStringSchema(),
+		nameKey:                      computedStringSchema(),
+		ciServiceKey:                 computedStringSchema(),
+		ciAdditionalTagsKey:          computedStringMapSchema(),
+		ciServiceRefreshRateKey:      {Type: schema.TypeInt, Computed: true},
+		ciDisabledKey:                computedBoolSchema(),
+		ciLastErrorKey:               computedStringSchema(),
+		ciLastErrorMsKey:             {Type: schema.TypeInt, Computed: true},
+		ciLastReceivedDataPointMsKey: {Type: schema.TypeInt, Computed: true},
+		ciLastMetricCountKey:         {Type: schema.TypeInt, Computed: true},
+		ciCloudWatchKey: computedBlockSchema(map[string]*schema.Schema{
+			"role_arn":                computedStringSchema(),
+			"external_id":             computedStringSchema(),
+			"metric_filter_regex":     computedStringSchema(),
+			"namespaces":              computedStringListSchema(),
+			"instance_selection_tags": computedStringMapSchema(),
+			"volume_selection_tags":   computedStringMapSchema(),
+			"point_tag_filter_regex":  computedStringSchema(),
+		}),
+		ciCloudTrailKey: computedBlockSchema(map[string]*schema.Schema{
+			"role_arn":    computedStringSchema(),
+			"external_id": computedStringSchema(),
+			"region":      computedStringSchema(),
+			"prefix":      computedStringSchema(),
+			"bucket_name": computedStringSchema(),
+			"filter_rule": computedStringSchema(),
+		}),
+		ciEc2Key: computedBlockSchema(map[string]*schema.Schema{
+			"role_arn":      computedStringSchema(),
+			"external_id":   computedStringSchema(),
+			"hostname_tags": computedStringListSchema(),
+		}),
+		ciGcpKey: computedBlockSchema(map[string]*schema.Schema{
+			"project_id":          computedStringSchema(),
+			"metric_filter_regex": computedStringSchema(),
+			"categories":          computedStringListSchema(),
+		}),
+		ciGcpBillingKey: computedBlockSchema(map[string]*schema.Schema{
+			"project_id": computedStringSchema(),
+		}),
+		ciNewRelicKey: computedBlockSchema(map[string]*schema.Schema{
+			"app_filter_regex":  computedStringSchema(),
+			"host_filter_regex": computedStringSchema(),
+			"metric_filter": computedBlockSchema(map[string]*schema.Schema{
+				"app_name":            computedStringSchema(),
+				"metric_filter_regex": computedStringSchema(),
+			}),
+		}),
+		ciAppDynamicsKey: computedBlockSchema(map[string]*schema.Schema{
+			"user_name":                       computedStringSchema(),
+			"controller_name":                 computedStringSchema(),
+			"enable_rollup":                   computedBoolSchema(),
+			"enable_error_metrics":            computedBoolSchema(),
+			"enable_business_trx_metrics":     computedBoolSchema(),
+			"enable_backend_metrics":          computedBoolSchema(),
+			"enable_overall_perf_metrics":     computedBoolSchema(),
+			"enable_individual_node_metrics":  computedBoolSchema(),
+			"enable_app_infra_metrics":        computedBoolSchema(),
+			"enable_service_endpoint_metrics": computedBoolSchema(),
+			"app_filter_regex":                computedStringListSchema(),
+		}),
+		ciAzureKey: computedBlockSchema(map[string]*schema.Schema{
+			"tenant":                computedStringSchema(),
+			"client_id":             computedStringSchema(),
+			"metric_filter_regex":   computedStringSchema(),
+			"category_filter":       computedStringListSchema(),
+			"resource_group_filter": computedStringListSchema(),
+		}),
+		ciAzureActivityLogKey: computedBlockSchema(map[string]*schema.Schema{
+			"tenant":          computedStringSchema(),
+			"client_id":       computedStringSchema(),
+			"category_filter": computedStringListSchema(),
+		}),
+		ciDynatraceKey: computedBlockSchema(map[string]*schema.Schema{
+			"environment_id":      computedStringSchema(),
+			"metric_filter_regex": computedStringSchema(),
+		}),
+		ciSnowflakeKey: computedBlockSchema(map[string]*schema.Schema{
+			"account_id":          computedStringSchema(),
+			"user_name":           computedStringSchema(),
+			"role":                computedStringSchema(),
+			"warehouse":           computedStringSchema(),
+			"metric_filter_regex": computedStringSchema(),
+		}),
+		ciVropsKey: computedBlockSchema(map[string]*schema.Schema{
+			"api_request": computedBlockSchema(map[string]*schema.Schema{
+				"base_url":  computedStringSchema(),
+				"user_name": computedStringSchema(),
+			}),
+			"categories":          computedStringListSchema(),
+			"metric_filter_regex": computedStringSchema(),
+		}),
+	}
+}
+
+func dataSourceCloudIntegrationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id := d.Get(idKey).(string)
+	item, err := searchOne(ctx, "cloudintegration", "id", id, m)
+	if err != nil {
+		return diag.Errorf("unable to find CloudIntegration with ID %s. %s", id, err)
+	}
+	var integration cloudIntegration
+	if err = json.Unmarshal(item, &integration); err != nil {
+		return diag.Errorf("Response is invalid JSON")
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	for key, value := range flattenCloudIntegration(&integration) {
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// flattenCloudIntegration returns the attributes of the cloud integration in
+// the data sources, leaving out its secrets: API keys and tokens, JSON keys,
+// passwords, private keys and client secrets.
+func flattenCloudIntegration(integration *cloudIntegration) map[string]interface{} {
+	lastError := integration.LastError
+	if lastError == "" && integration.LastErrorEvent != nil {
+		lastError = integration.LastErrorEvent.Name
+	}
+	tfMap := map[string]interface{}{
+		idKey:                        integration.Id,
+		nameKey:                      integration.Name,
+		ciServiceKey:                 integration.Service,
+		ciAdditionalTagsKey:          integration.AdditionalTags,
+		ciServiceRefreshRateKey:      integration.ServiceRefreshRateInMins,
+		ciDisabledKey:                integration.Disabled,
+		ciLastErrorKey:               lastError,
+		ciLastErrorMsKey:             integration.LastErrorMs,
+		ciLastReceivedDataPointMsKey: integration.LastReceivedDataPointMs,
+		ciLastMetricCountKey:         integration.LastMetricCount,
+	}
+
+	block := func(key string, attributes map[string]interface{}) {
+		tfMap[key] = []interface{}{attributes}
+	}
+	aws := func(credentials *wavefront.AWSBaseCredentials, attributes map[string]interface{}) map[string]interface{} {
+		if credentials != nil {
+			attributes["role_arn"] = credentials.RoleARN
+			attributes["external_id"] = credentials.ExternalID
+		}
+		return attributes
+	}
+	azure := func(credentials *wavefront.AzureBaseCredentials, attributes map[string]interface{}) map[string]interface{} {
+		if credentials != nil {
+			attributes["tenant"] = credentials.Tenant
+			attributes["client_id"] = credentials.ClientID
+		}
+		return attributes
+	}
+
+	if c := integration.CloudWatch; c != nil {
+		block(ciCloudWatchKey, aws(c.BaseCredentials, map[string]interface{}{
+			"metric_filter_regex":     c.MetricFilterRegex,
+			"namespaces":              c.Namespaces,
+			"instance_selection_tags": c.InstanceSelectionTags,
+			"volume_selection_tags":   c.VolumeSelectionTags,
+			"point_tag_filter_regex":  c.PointTagFilterRegex,
+		}))
+	}
+	if c := integration.CloudTrail; c != nil {
+		block(ciCloudTrailKey, aws(c.BaseCredentials, map[string]interface{}{
+			"region":      c.Region,
+			"prefix":      c.Prefix,
+			"bucket_name": c.BucketName,
+			"filter_rule": c.FilterRule,
+		}))
+	}
+	if c := integration.EC2; c != nil {
+		block(ciEc2Key, aws(c.BaseCredentials, map[string]interface{}{
+			"hostname_tags": c.HostNameTags,
+		}))
+	}
+	if c := integration.GCP; c != nil {
+		block(ciGcpKey, map[string]interface{}{
+			"project_id":          c.ProjectId,
+			"metric_filter_regex": c.MetricFilterRegex,
+			"categories":          c.CategoriesToFetch,
+		})
+	}
+	if c := integration.GCPBilling; c != nil {
+		block(ciGcpBillingKey, map[string]interface{}{
+			"project_id": c.ProjectId,
+		})
+	}
+	if c := integration.NewRelic; c != nil {
+		metricFilters := make([]interface{}, 0, len(c.NewRelicMetricFilters))
+		for _, f := range c.NewRelicMetricFilters {
+			metricFilters = append(metricFilters, map[string]interface{}{
+				"app_name":            f.AppName,
+				"metric_filter_regex": f.MetricFilterRegex,
+			})
+		}
+		block(ciNewRelicKey, map[string]interface{}{
+			"app_filter_regex":  c.AppFilterRegex,
+			"host_filter_regex": c.HostFilterRegex,
+			"metric_filter":     metricFilters,
+		})
+	}
+	if c := integration.AppDynamics; c != nil {
+		block(ciAppDynamicsKey, map[string]interface{}{
+			"user_name":                       c.UserName,
+			"controller_name":                 c.ControllerName,
+			"enable_rollup":                   c.EnableRollup,
+			"enable_error_metrics":            c.EnableErrorMetrics,
+			"enable_business_trx_metrics":     c.EnableBusinessTrxMetrics,
+			"enable_backend_metrics":          c.EnableBackendMetrics,
+			"enable_overall_perf_metrics":     c.EnableOverallPerfMetrics,
+			"enable_individual_node_metrics":  c.EnableIndividualNodeMetrics,
+			"enable_app_infra_metrics":        c.EnableAppInfraMetrics,
+			"enable_service_endpoint_metrics": c.EnableServiceEndpointMetrics,
+			"app_filter_regex":                c.AppFilterRegex,
+		})
+	}
+	if c := integration.Azure; c != nil {
+		block(ciAzureKey, azure(c.BaseCredentials, map[string]interface{}{
+			"metric_filter_regex":   c.MetricFilterRegex,
+			"category_filter":       c.CategoryFilter,
+			"resource_group_filter": c.ResourceGroupFilter,
+		}))
+	}
+	if c := integration.AzureActivityLog; c != nil {
+		block(ciAzureActivityLogKey, azure(c.BaseCredentials, map[string]interface{}{
+			"category_filter": c.CategoryFilter,
+		}))
+	}
+	if c := integration.Dynatrace; c != nil {
+		block(ciDynatraceKey, map[string]interface{}{
+			"environment_id":      c.EnvironmentID,
+			"metric_filter_regex": c.MetricFilterRegex,
+		})
+	}
+	if c := integration.Snowflake; c != nil {
+		block(ciSnowflakeKey, map[string]interface{}{
+			"account_id":          c.AccountID,
+			"user_name":           c.UserName,
+			"role":                c.Role,
+			"warehouse":           c.Warehouse,
+			"metric_filter_regex": c.MetricFilterRegex,
+		})
+	}
+	if c := integration.Vrops; c != nil {
+		apiRequests := make([]interface{}, 0, len(c.VropsAPIRequest))
+		for _, r := range c.VropsAPIRequest {
+			apiRequests = append(apiRequests, map[string]interface{}{
+				"base_url":  r.BaseURL,
+				"user_name": r.UserName,
+			})
+		}
+		block(ciVropsKey, map[string]interface{}{
+			"api_request":         apiRequests,
+			"categories":          c.CategoriesToFetch,
+			"metric_filter_regex": c.MetricFilterRegex,
+		})
+	}
+	return tfMap
+}
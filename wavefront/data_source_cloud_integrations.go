@@ -0,0 +1,76 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const cloudIntegrationsKey = "cloud_integrations"
+
+func dataSourceCloudIntegrations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCloudIntegrationsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceCloudIntegrationsSchema(),
+	}
+}
+
+func dataSourceCloudIntegrationsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		cloudIntegrationsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: cloudIntegrationSchema()},
+		},
+		ciServiceKey: {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		limitKey: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		offsetKey: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		filterKey: searchFilterSchema(),
+	}
+}
+
+func dataSourceCloudIntegrationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var allCloudIntegrations []*cloudIntegration
+
+	limit := d.Get(limitKey).(int)
+	offset := d.Get(offsetKey).(int)
+	filter := expandSearchFilters(d)
+	if service, ok := d.GetOk(ciServiceKey); ok {
+		filter = append(filter, &searchCondition{Key: "service", Value: service.(string), MatchingMethod: "EXACT"})
+	}
+
+	items, err := searchAll(ctx, limit, offset, "cloudintegration", nil, filter, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allCloudIntegrations); err != nil {
+		return diag.Errorf("Response is invalid JSON")
+	}
+
+	tfMaps := make([]map[string]interface{}, len(allCloudIntegrations))
+	for i, integration := range allCloudIntegrations {
+		tfMaps[i] = flattenCloudIntegration(integration)
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	return diag.FromErr(d.Set(cloudIntegrationsKey, tfMaps))
+}
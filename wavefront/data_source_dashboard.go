@@ -1,11 +1,13 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -122,8 +124,9 @@ const (
 
 func dataSourceDashboard() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceDashboardRead,
-		Schema: dataSourceDashboardSchema(),
+		ReadContext: dataSourceDashboardRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceDashboardSchema(),
 	}
 }
 
@@ -769,23 +772,23 @@ func parameterDetailSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceDashboardRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceDashboardRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
-	dashboardClient := m.(*wavefrontClient).client.Dashboards()
+	dashboardClient := m.(*wavefrontClient).withContext(ctx).Dashboards()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	dashboard := wavefront.Dashboard{ID: idStr}
 	if err := dashboardClient.Get(&dashboard); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setDashboardAttributes(d, dashboard)
+	return diag.FromErr(setDashboardAttributes(d, dashboard))
 
 }
 
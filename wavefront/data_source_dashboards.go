@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceDashboards() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceDashboardsRead,
-		Schema: dataSourceDashboardsSchema(),
+		ReadContext: dataSourceDashboardsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceDashboardsSchema(),
 	}
 }
 
@@ -27,33 +30,38 @@ func dataSourceDashboardsSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  0,
 		},
+		filterKey: searchFilterSchema(),
 	}
 
 }
 
-func dataSourceDashboardsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceDashboardsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var allDashboards []*wavefront.Dashboard
 
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "dashboard", nil, nil, m), &allDashboards); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "dashboard", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allDashboards); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set("dashboards", flattenDashboards(allDashboards))
+	return diag.FromErr(d.Set("dashboards", flattenDashboards(allDashboards)))
 }
 
 func flattenDashboards(dashboards []*wavefront.Dashboard) interface{} {
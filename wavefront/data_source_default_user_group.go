@@ -1,16 +1,18 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceDefaultUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceDefaultUserGroupRead,
+		ReadContext: dataSourceDefaultUserGroupRead,
+		Timeouts:    dataSourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"group_id": {
 				Type:     schema.TypeString,
@@ -20,8 +22,8 @@ func dataSourceDefaultUserGroup() *schema.Resource {
 	}
 }
 
-func dataSourceDefaultUserGroupRead(d *schema.ResourceData, m interface{}) error {
-	userGroups := m.(*wavefrontClient).client.UserGroups()
+func dataSourceDefaultUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	userGroups := m.(*wavefrontClient).withContext(ctx).UserGroups()
 
 	results, err := userGroups.Find(
 		[]*wavefront.SearchCondition{
@@ -34,11 +36,11 @@ func dataSourceDefaultUserGroupRead(d *schema.ResourceData, m interface{}) error
 	)
 
 	if err != nil {
-		return fmt.Errorf("error reading Default UserGroup 'Everyone' in Wavefront, %s", err)
+		return diag.Errorf("error reading Default UserGroup 'Everyone' in Wavefront, %s", err)
 	}
 
 	if len(results) != 1 {
-		return fmt.Errorf("error finding default UserGroup 'Everyone' in Wavefront")
+		return diag.Errorf("error finding default UserGroup 'Everyone' in Wavefront")
 	}
 
 	userGroup := results[0]
@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceDerivedMetric() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceDerivedMetricRead,
-		Schema: derivedMetricResponseSchema(),
+		ReadContext: dataSourceDerivedMetricRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      derivedMetricResponseSchema(),
 	}
 }
 
@@ -137,22 +140,22 @@ func derivedMetricResponseSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceDerivedMetricRead(d *schema.ResourceData, m interface{}) error {
-	derivedMetricClient := m.(*wavefrontClient).client.DerivedMetrics()
+func dataSourceDerivedMetricRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	derivedMetricClient := m.(*wavefrontClient).withContext(ctx).DerivedMetrics()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	derivedMetric := wavefront.DerivedMetric{ID: &idStr}
 	if err := derivedMetricClient.Get(&derivedMetric); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setDerivedMetricAttributes(d, derivedMetric)
+	return diag.FromErr(setDerivedMetricAttributes(d, derivedMetric))
 }
 
 func setDerivedMetricAttributes(d *schema.ResourceData, derivedMetric wavefront.DerivedMetric) error {
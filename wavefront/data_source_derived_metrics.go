@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceDerivedMetrics() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceDerivedMetricsRead,
-		Schema: dataSourceDerivedMetricsSchema(),
+		ReadContext: dataSourceDerivedMetricsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceDerivedMetricsSchema(),
 	}
 }
 
@@ -27,15 +30,16 @@ func dataSourceDerivedMetricsSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  0,
 		},
+		filterKey: searchFilterSchema(),
 	}
 }
 
@@ -161,20 +165,24 @@ func derivedMetricSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceDerivedMetricsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceDerivedMetricsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var allDerivedMetrics []*wavefront.DerivedMetric
 
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "derivedmetric", nil, nil, m), &allDerivedMetrics); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "derivedmetric", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allDerivedMetrics); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set(derivedMetricsKey, flattenDerivedMetrics(allDerivedMetrics))
+	return diag.FromErr(d.Set(derivedMetricsKey, flattenDerivedMetrics(allDerivedMetrics)))
 }
 
 func flattenDerivedMetrics(derivedMetrics []*wavefront.DerivedMetric) []map[string]interface{} {
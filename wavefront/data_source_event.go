@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceEvent() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceEventRead,
-		Schema: dataSourceEventSchema(),
+		ReadContext: dataSourceEventRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceEventSchema(),
 	}
 }
 
@@ -73,11 +76,11 @@ func dataSourceEventSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceEventRead(d *schema.ResourceData, m interface{}) error {
-	eventClient := m.(*wavefrontClient).client.Events()
+func dataSourceEventRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	eventClient := m.(*wavefrontClient).withContext(ctx).Events()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
@@ -85,11 +88,11 @@ func dataSourceEventRead(d *schema.ResourceData, m interface{}) error {
 	var event *wavefront.Event
 	var err error
 	if event, err = eventClient.FindByID(idStr); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(time.Now().UTC().String())
-	return setEventAttributes(d, *event)
+	return diag.FromErr(setEventAttributes(d, *event))
 }
 
 func setEventAttributes(d *schema.ResourceData, event wavefront.Event) error {
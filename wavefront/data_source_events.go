@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceEvents() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceEventsRead,
-		Schema: dataSourceEventsSchema(),
+		ReadContext: dataSourceEventsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceEventsSchema(),
 	}
 }
 
@@ -34,9 +37,9 @@ func dataSourceEventsSchema() map[string]*schema.Schema {
 			Required: true,
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
@@ -104,18 +107,18 @@ func dataSourceEventEntitySchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceEventsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceEventsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 
 	var allEvents []*wavefront.Event
 
 	earliestStartTimeEpochMillis, ok1 := d.GetOk("earliest_start_time_epoch_millis")
 	if !ok1 {
-		return fmt.Errorf("required parameter earliest_start_time_epoch_millis not set")
+		return diag.Errorf("required parameter earliest_start_time_epoch_millis not set")
 	}
 
 	latestStartTimeEpochMillis, ok2 := d.GetOk("latest_start_time_epoch_millis")
 	if !ok2 {
-		return fmt.Errorf("required parameter latest_start_time_epoch_millis not set")
+		return diag.Errorf("required parameter latest_start_time_epoch_millis not set")
 	}
 
 	var earliestStartTimeEpochMillisInt64 int64
@@ -132,14 +135,18 @@ func dataSourceEventsRead(d *schema.ResourceData, m interface{}) error {
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "event", &timeRange, nil, m), &allEvents); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "event", &timeRange, nil, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allEvents); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set("events", flattenEvents(allEvents))
+	return diag.FromErr(d.Set("events", flattenEvents(allEvents)))
 }
 
 func flattenEvents(events []*wavefront.Event) interface{} {
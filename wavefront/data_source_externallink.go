@@ -1,10 +1,12 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,8 +21,9 @@ const (
 
 func dataSourceExternalLink() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceExternalLinkRead,
-		Schema: dataSourceExternalLinkSchema(),
+		ReadContext: dataSourceExternalLinkRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceExternalLinkSchema(),
 	}
 }
 
@@ -91,22 +94,22 @@ func dataSourceExternalLinkSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceExternalLinkRead(d *schema.ResourceData, m interface{}) error {
-	externalLinkClient := m.(*wavefrontClient).client.ExternalLinks()
+func dataSourceExternalLinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	externalLinkClient := m.(*wavefrontClient).withContext(ctx).ExternalLinks()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	extLink := wavefront.ExternalLink{ID: &idStr}
 	if err := externalLinkClient.Get(&extLink); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setExternalLinkAttributes(d, extLink)
+	return diag.FromErr(setExternalLinkAttributes(d, extLink))
 }
 
 func setExternalLinkAttributes(d *schema.ResourceData, extLink wavefront.ExternalLink) error {
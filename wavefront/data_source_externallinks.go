@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceExternalLinks() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceExternalLinksRead,
-		Schema: dataSourceExternalLinksSchema(),
+		ReadContext: dataSourceExternalLinksRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceExternalLinksSchema(),
 	}
 }
 
@@ -27,33 +30,38 @@ func dataSourceExternalLinksSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  0,
 		},
+		filterKey: searchFilterSchema(),
 	}
 
 }
 
-func dataSourceExternalLinksRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceExternalLinksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var allExternalLinks []*wavefront.ExternalLink
 
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "extlink", nil, nil, m), &allExternalLinks); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "extlink", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allExternalLinks); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set(externalLinksKey, flattenExternalLinks(allExternalLinks))
+	return diag.FromErr(d.Set(externalLinksKey, flattenExternalLinks(allExternalLinks)))
 }
 
 func flattenExternalLinks(externalLinks []*wavefront.ExternalLink) []map[string]interface{} {
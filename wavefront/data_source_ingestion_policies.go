@@ -0,0 +1,68 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const ingestionPoliciesKey = "ingestion_policies"
+
+func dataSourceIngestionPolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIngestionPoliciesRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceIngestionPoliciesSchema(),
+	}
+}
+
+func dataSourceIngestionPoliciesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		ingestionPoliciesKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: ingestionPolicySchema()},
+		},
+		limitKey: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		offsetKey: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		filterKey: searchFilterSchema(),
+	}
+}
+
+func dataSourceIngestionPoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var allIngestionPolicies []*wavefront.IngestionPolicyResponse
+
+	limit := d.Get(limitKey).(int)
+	offset := d.Get(offsetKey).(int)
+
+	items, err := searchAll(ctx, limit, offset, "ingestionpolicy", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allIngestionPolicies); err != nil {
+		return diag.Errorf("Response is invalid JSON")
+	}
+
+	tfMaps := make([]map[string]interface{}, len(allIngestionPolicies))
+	for i, ingestionPolicy := range allIngestionPolicies {
+		tfMaps[i] = flattenDataSourceIngestionPolicy(ingestionPolicy)
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	return diag.FromErr(d.Set(ingestionPoliciesKey, tfMaps))
+}
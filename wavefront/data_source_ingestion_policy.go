@@ -0,0 +1,135 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceIngestionPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceIngestionPolicyRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceIngestionPolicySchema(),
+	}
+}
+
+// dataSourceIngestionPolicySchema is the schema of the ingestion policy data
+// source, looked up by its id or its name.
+func dataSourceIngestionPolicySchema() map[string]*schema.Schema {
+	s := ingestionPolicySchema()
+	s[idKey] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{idKey, ipNameKey},
+	}
+	s[ipNameKey] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{idKey, ipNameKey},
+	}
+	return s
+}
+
+// ingestionPolicySchema is the schema of the ingestion policies of the data
+// sources.
+func ingestionPolicySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		idKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		ipNameKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		ipDescriptionKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		ipScopeKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		ipAccountsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		ipGroupsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		ipSourcesKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		ipNamespacesKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		ipTagsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					ipKeyKey: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					ipValueKey: {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceIngestionPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var ingestionPolicy *wavefront.IngestionPolicyResponse
+	if id, ok := d.GetOk(idKey); ok {
+		var err error
+		ingestionPolicy, err = m.(*wavefrontClient).withContext(ctx).IngestionPolicies().GetByID(id.(string))
+		if err != nil {
+			return diag.Errorf("error finding Wavefront Ingestion Policy %s. %s", id, err)
+		}
+	} else {
+		name := d.Get(ipNameKey).(string)
+		item, err := searchOne(ctx, "ingestionpolicy", "name", name, m)
+		if err != nil {
+			return diag.Errorf("error finding Wavefront Ingestion Policy %q. %s", name, err)
+		}
+		if err = json.Unmarshal(item, &ingestionPolicy); err != nil {
+			return diag.Errorf("Response is invalid JSON")
+		}
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	for key, value := range flattenDataSourceIngestionPolicy(ingestionPolicy) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// flattenDataSourceIngestionPolicy returns the attributes of the ingestion
+// policy in the data sources: those of the resource and its id.
+func flattenDataSourceIngestionPolicy(ingestionPolicy *wavefront.IngestionPolicyResponse) map[string]interface{} {
+	tfMap := flattenIngestionPolicy(ingestionPolicy)
+	tfMap[idKey] = ingestionPolicy.ID
+	return tfMap
+}
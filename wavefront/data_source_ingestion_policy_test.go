@@ -0,0 +1,82 @@
+package wavefront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestionPolicyDataSources_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	ctx := context.Background()
+
+	sources, err := client.IngestionPolicies().Create(&wavefront.IngestionPolicyRequest{
+		Name:    "Prod Sources",
+		Scope:   "SOURCES",
+		Sources: []string{"prod-1", "prod-2"},
+	})
+	require.NoError(t, err)
+	_, err = client.IngestionPolicies().Create(&wavefront.IngestionPolicyRequest{
+		Name:       "App Namespaces",
+		Scope:      "NAMESPACES",
+		Namespaces: []string{"app"},
+	})
+	require.NoError(t, err)
+
+	ingestionPolicy := dataSourceIngestionPolicy()
+	d := schema.TestResourceDataRaw(t, ingestionPolicy.Schema, map[string]interface{}{ipNameKey: "Prod Sources"})
+	diags := ingestionPolicy.ReadContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, sources.ID, d.Get(idKey))
+	assert.Equal(t, "SOURCES", d.Get(ipScopeKey))
+	assert.Equal(t, []interface{}{"prod-1", "prod-2"}, d.Get(ipSourcesKey))
+
+	byID := schema.TestResourceDataRaw(t, ingestionPolicy.Schema, map[string]interface{}{idKey: sources.ID})
+	diags = ingestionPolicy.ReadContext(ctx, byID, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Prod Sources", byID.Get(ipNameKey))
+
+	ingestionPolicies := dataSourceIngestionPolicies()
+	all := schema.TestResourceDataRaw(t, ingestionPolicies.Schema, map[string]interface{}{})
+	diags = ingestionPolicies.ReadContext(ctx, all, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, all.Get("ingestion_policies.#"))
+	assert.Equal(t, "app", all.Get("ingestion_policies.1.namespaces.0"))
+}
+
+func TestAccWavefrontIngestionPolicyDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontIngestionPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontIngestionPolicyDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.wavefront_ingestion_policy.by_name", "id", "wavefront_ingestion_policy.tester", "id"),
+					resource.TestCheckResourceAttr("data.wavefront_ingestion_policy.by_name", "sources.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckWavefrontIngestionPolicyDataSource = `
+resource "wavefront_ingestion_policy" "tester" {
+  name        = "Test Ingestion Policy Data Source"
+  description = "Ingestion policy for Terraform test"
+  scope       = "SOURCES"
+  sources     = ["tftesting"]
+}
+
+data "wavefront_ingestion_policy" "by_name" {
+  name = wavefront_ingestion_policy.tester.name
+}
+`
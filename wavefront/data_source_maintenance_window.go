@@ -1,10 +1,12 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -27,8 +29,9 @@ const (
 
 func dataSourceMaintenanceWindow() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceMaintenanceWindowRead,
-		Schema: dataSourceMaintenanceWindowSchema(),
+		ReadContext: dataSourceMaintenanceWindowRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceMaintenanceWindowSchema(),
 	}
 }
 
@@ -135,23 +138,23 @@ func dataSourceMaintenanceWindowSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceMaintenanceWindowRead(d *schema.ResourceData, m interface{}) error {
-	maintenanceWindowClient := m.(*wavefrontClient).client.MaintenanceWindows()
+func dataSourceMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	maintenanceWindowClient := m.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	maintenanceWindow, err := maintenanceWindowClient.GetByID(idStr)
 
 	if err != nil {
-		return fmt.Errorf("error finding maintenance window with id %s", idStr)
+		return diag.Errorf("error finding maintenance window with id %s", idStr)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setMaintenanceWindowAttributes(d, maintenanceWindow)
+	return diag.FromErr(setMaintenanceWindowAttributes(d, maintenanceWindow))
 }
 
 func setMaintenanceWindowAttributes(d *schema.ResourceData, maintenanceWindow *wavefront.MaintenanceWindow) error {
@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceMaintenanceWindows() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceMaintenanceWindowsRead,
-		Schema: dataSourceMaintenanceWindowsSchema(),
+		ReadContext: dataSourceMaintenanceWindowsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceMaintenanceWindowsSchema(),
 	}
 }
 
@@ -27,33 +30,38 @@ func dataSourceMaintenanceWindowsSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
 			Optional: true,
 			Default:  0,
 		},
+		filterKey: searchFilterSchema(),
 	}
 
 }
 
-func dataSourceMaintenanceWindowsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceMaintenanceWindowsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var allMaintenanceWindows []*wavefront.MaintenanceWindow
 
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "maintenancewindow", nil, nil, m), &allMaintenanceWindows); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "maintenancewindow", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allMaintenanceWindows); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 
-	return d.Set(maintenanceWindowsKey, flattenMaintenanceWindows(allMaintenanceWindows))
+	return diag.FromErr(d.Set(maintenanceWindowsKey, flattenMaintenanceWindows(allMaintenanceWindows)))
 }
 
 func flattenMaintenanceWindows(maintenanceWindows []*wavefront.MaintenanceWindow) []map[string]interface{} {
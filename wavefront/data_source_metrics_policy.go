@@ -1,37 +1,39 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceMetricsPolicy() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceMetricsPolicyRead,
-		Schema: dataSourceMetricsPolicySchema(),
+		ReadContext: dataSourceMetricsPolicyRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceMetricsPolicySchema(),
 	}
 }
 
-func dataSourceMetricsPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	metrics := meta.(*wavefrontClient).client.MetricsPolicyAPI()
+func dataSourceMetricsPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
 	metricsPolicy, err := metrics.Get()
 	if err != nil {
-		return fmt.Errorf("error retrieving metrics policy: %d", err)
+		return diag.Errorf("error retrieving metrics policy: %d", err)
 	}
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 	if err := d.Set(policyRulesKey, flattenPolicyRules(metricsPolicy.PolicyRules)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(customerKey, metricsPolicy.Customer); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(updaterIDKey, metricsPolicy.UpdaterId); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return d.Set(updatedEpochMillisKey, metricsPolicy.UpdatedEpochMillis)
+	return diag.FromErr(d.Set(updatedEpochMillisKey, metricsPolicy.UpdatedEpochMillis))
 }
 
 func dataSourceMetricsPolicySchema() map[string]*schema.Schema {
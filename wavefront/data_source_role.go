@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceRole() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceRoleRead,
-		Schema: roleSchema(),
+		ReadContext: dataSourceRoleRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      roleSchema(),
 	}
 }
 
@@ -38,22 +41,22 @@ func roleSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceRoleRead(d *schema.ResourceData, m interface{}) error {
-	roleClient := m.(*wavefrontClient).client.Roles()
+func dataSourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	roleClient := m.(*wavefrontClient).withContext(ctx).Roles()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	role := wavefront.Role{ID: idStr}
 	if err := roleClient.Get(&role); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setRoleAttributes(d, role)
+	return diag.FromErr(setRoleAttributes(d, role))
 }
 
 func setRoleAttributes(d *schema.ResourceData, role wavefront.Role) error {
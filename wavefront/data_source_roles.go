@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceRoles() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceRolesRead,
-		Schema: dataSourceRolesSchema(),
+		ReadContext: dataSourceRolesRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceRolesSchema(),
 	}
 }
 
@@ -24,9 +27,9 @@ func dataSourceRolesSchema() map[string]*schema.Schema {
 			Elem:     &schema.Resource{Schema: rolesSchema()},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
@@ -36,19 +39,23 @@ func dataSourceRolesSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceRolesRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceRolesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var allRoles []*wavefront.Role
 
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "role", nil, nil, m), &allRoles); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "role", nil, nil, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allRoles); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return d.Set(rolesKey, flattenRoles(allRoles))
+	return diag.FromErr(d.Set(rolesKey, flattenRoles(allRoles)))
 }
 
 func flattenRoles(roles []*wavefront.Role) []map[string]interface{} {
@@ -0,0 +1,93 @@
+package wavefront
+
+import (
+	"context"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServiceAccountRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceServiceAccountSchema(),
+	}
+}
+
+// dataSourceServiceAccountSchema is the schema of the service account data
+// source, looked up by its id, which is its identifier.
+func dataSourceServiceAccountSchema() map[string]*schema.Schema {
+	s := serviceAccountSchema()
+	s[idKey] = &schema.Schema{
+		Type:     schema.TypeString,
+		Required: true,
+	}
+	return s
+}
+
+// serviceAccountSchema is the schema of the service accounts of the data
+// sources.
+func serviceAccountSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		idKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"identifier": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"active": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+		descriptionKey: {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"permissions": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"user_groups": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ingestion_policy": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+func dataSourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	serviceAccounts := m.(*wavefrontClient).withContext(ctx).ServiceAccounts()
+	id := d.Get(idKey).(string)
+	serviceAccount, err := serviceAccounts.GetByID(id)
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Service Account %s. %s", id, err)
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	for key, value := range flattenDataSourceServiceAccount(serviceAccount) {
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+// flattenDataSourceServiceAccount returns the attributes of the service
+// account in the data sources: those of the resource and its id.
+func flattenDataSourceServiceAccount(serviceAccount *wavefront.ServiceAccount) map[string]interface{} {
+	tfMap := flattenServiceAccount(serviceAccount)
+	tfMap[idKey] = serviceAccount.ID
+	return tfMap
+}
@@ -0,0 +1,78 @@
+package wavefront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAccountDataSources_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	ctx := context.Background()
+
+	for _, id := range []string{"sa::deploy", "sa::metrics"} {
+		_, err := client.ServiceAccounts().Create(&wavefront.ServiceAccountOptions{
+			ID:          id,
+			Active:      true,
+			Description: "Service account " + id,
+			Permissions: []string{"alerts_management"},
+		})
+		require.NoError(t, err)
+	}
+
+	serviceAccount := dataSourceServiceAccount()
+	d := schema.TestResourceDataRaw(t, serviceAccount.Schema, map[string]interface{}{idKey: "sa::metrics"})
+	diags := serviceAccount.ReadContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "sa::metrics", d.Get("identifier"))
+	assert.Equal(t, "Service account sa::metrics", d.Get(descriptionKey))
+	assert.True(t, d.Get("active").(bool))
+	assert.Equal(t, []interface{}{"alerts_management"}, d.Get("permissions"))
+
+	serviceAccounts := dataSourceServiceAccounts()
+	filtered := schema.TestResourceDataRaw(t, serviceAccounts.Schema, map[string]interface{}{
+		filterKey: []interface{}{map[string]interface{}{
+			filterKeyKey:      "identifier",
+			filterValueKey:    "deploy",
+			matchingMethodKey: "CONTAINS",
+		}},
+	})
+	diags = serviceAccounts.ReadContext(ctx, filtered, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, filtered.Get("service_accounts.#"))
+	assert.Equal(t, "sa::deploy", filtered.Get("service_accounts.0.id"))
+}
+
+func TestAccWavefrontServiceAccountDataSource_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontServiceAccountDataSource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wavefront_service_account.by_id", "identifier", "sa::tftesting"),
+					resource.TestCheckResourceAttr("data.wavefront_service_account.by_id", "description", "Data source test"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckWavefrontServiceAccountDataSource = `
+resource "wavefront_service_account" "basic" {
+  identifier  = "sa::tftesting"
+  description = "Data source test"
+}
+
+data "wavefront_service_account" "by_id" {
+  id = wavefront_service_account.basic.id
+}
+`
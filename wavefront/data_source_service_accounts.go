@@ -0,0 +1,68 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const serviceAccountsKey = "service_accounts"
+
+func dataSourceServiceAccounts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServiceAccountsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceServiceAccountsSchema(),
+	}
+}
+
+func dataSourceServiceAccountsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		// Computed Values
+		serviceAccountsKey: {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Resource{Schema: serviceAccountSchema()},
+		},
+		limitKey: {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		offsetKey: {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Default:  0,
+		},
+		filterKey: searchFilterSchema(),
+	}
+}
+
+func dataSourceServiceAccountsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var allServiceAccounts []*wavefront.ServiceAccount
+
+	limit := d.Get(limitKey).(int)
+	offset := d.Get(offsetKey).(int)
+
+	items, err := searchAll(ctx, limit, offset, "serviceaccount", nil, expandSearchFilters(d), m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allServiceAccounts); err != nil {
+		return diag.Errorf("Response is invalid JSON")
+	}
+
+	tfMaps := make([]map[string]interface{}, len(allServiceAccounts))
+	for i, serviceAccount := range allServiceAccounts {
+		tfMaps[i] = flattenDataSourceServiceAccount(serviceAccount)
+	}
+
+	// Data Source ID is set to current time to always refresh
+	d.SetId(time.Now().UTC().String())
+	return diag.FromErr(d.Set(serviceAccountsKey, tfMaps))
+}
@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceUser() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceUserRead,
-		Schema: dataSourceUserSchema(),
+		ReadContext: dataSourceUserRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceUserSchema(),
 	}
 }
 
@@ -44,20 +47,20 @@ func dataSourceUserSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceUserRead(d *schema.ResourceData, m interface{}) error {
-	userClient := m.(*wavefrontClient).client.Users()
+func dataSourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	userClient := m.(*wavefrontClient).withContext(ctx).Users()
 	id, ok := d.GetOk(emailKey)
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", emailKey)
+		return diag.Errorf("required parameter '%s' not set", emailKey)
 	}
 	idStr := fmt.Sprintf("%s", id)
 	user := wavefront.User{ID: &idStr}
 	if err := userClient.Get(&user); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setUserAttributes(d, user)
+	return diag.FromErr(setUserAttributes(d, user))
 }
 
 func setUserAttributes(d *schema.ResourceData, user wavefront.User) error {
@@ -1,17 +1,20 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceUserGroupRead,
-		Schema: userGroupNewSchema(),
+		ReadContext: dataSourceUserGroupRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      userGroupNewSchema(),
 	}
 }
 
@@ -43,22 +46,22 @@ func userGroupNewSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceUserGroupRead(d *schema.ResourceData, m interface{}) error {
-	userGroupClient := m.(*wavefrontClient).client.UserGroups()
+func dataSourceUserGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	userGroupClient := m.(*wavefrontClient).withContext(ctx).UserGroups()
 	id, ok := d.GetOk("id")
 	if !ok {
-		return fmt.Errorf("required parameter '%s' not set", idKey)
+		return diag.Errorf("required parameter '%s' not set", idKey)
 	}
 
 	idStr := fmt.Sprintf("%s", id)
 	userGroup := wavefront.UserGroup{ID: &idStr}
 	if err := userGroupClient.Get(&userGroup); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return setUserGroupAttributes(d, userGroup)
+	return diag.FromErr(setUserGroupAttributes(d, userGroup))
 }
 
 func setUserGroupAttributes(d *schema.ResourceData, userGroup wavefront.UserGroup) error {
@@ -1,18 +1,21 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceUserGroups() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceUserGroupsRead,
-		Schema: dataSourceUserGroupsSchema(),
+		ReadContext: dataSourceUserGroupsRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceUserGroupsSchema(),
 	}
 }
 
@@ -27,9 +30,9 @@ func dataSourceUserGroupsSchema() map[string]*schema.Schema {
 			},
 		},
 		limitKey: {
-			Type:     schema.TypeInt,
-			Optional: true,
-			Default:  100,
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
 		},
 		offsetKey: {
 			Type:     schema.TypeInt,
@@ -67,7 +70,7 @@ func userGroupSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceUserGroupsRead(d *schema.ResourceData, m interface{}) error {
+func dataSourceUserGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
 	var allGroups []*wavefront.UserGroup
@@ -75,11 +78,15 @@ func dataSourceUserGroupsRead(d *schema.ResourceData, m interface{}) error {
 	limit := d.Get(limitKey).(int)
 	offset := d.Get(offsetKey).(int)
 
-	if err := json.Unmarshal(searchAll(limit, offset, "usergroup", nil, nil, m), &allGroups); err != nil {
-		return fmt.Errorf("Response is invalid JSON")
+	items, err := searchAll(ctx, limit, offset, "usergroup", nil, nil, m)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &allGroups); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 
-	return d.Set(userGroupsListKey, flattenUserGroups(allGroups))
+	return diag.FromErr(d.Set(userGroupsListKey, flattenUserGroups(allGroups)))
 }
 
 func flattenUserGroups(users []*wavefront.UserGroup) []map[string]interface{} {
@@ -1,16 +1,20 @@
 package wavefront
 
 import (
+	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceUsers() *schema.Resource {
 	return &schema.Resource{
-		Read:   dataSourceUsersRead,
-		Schema: dataSourceUsersSchema(),
+		ReadContext: dataSourceUsersRead,
+		Timeouts:    dataSourceTimeouts(),
+		Schema:      dataSourceUsersSchema(),
 	}
 }
 
@@ -23,6 +27,7 @@ func dataSourceUsersSchema() map[string]*schema.Schema {
 				Schema: userSchema(),
 			},
 		},
+		filterKey: searchFilterSchema(),
 	}
 }
 
@@ -54,16 +59,18 @@ func userSchema() map[string]*schema.Schema {
 	}
 }
 
-func dataSourceUsersRead(d *schema.ResourceData, m interface{}) error {
-	userClient := m.(*wavefrontClient).client.Users()
-
-	users, err := userClient.Find(nil)
+func dataSourceUsersRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var users []*wavefront.User
+	items, err := searchAll(ctx, 0, 0, "user", nil, expandSearchFilters(d), m)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err := json.Unmarshal(items, &users); err != nil {
+		return diag.Errorf("Response is invalid JSON")
 	}
 	// Data Source ID is set to current time to always refresh
 	d.SetId(time.Now().UTC().String())
-	return d.Set(usersKey, flattenUsers(users))
+	return diag.FromErr(d.Set(usersKey, flattenUsers(users)))
 }
 
 func flattenUsers(users []*wavefront.User) []map[string]interface{} {
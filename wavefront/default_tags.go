@@ -0,0 +1,114 @@
+package wavefront
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	defaultTagsKey = "default_tags"
+	tagsAllKey     = "tags_all"
+)
+
+// defaultTagsSchema is the schema of the default_tags block of the provider,
+// holding the tags added to every alert, derived metric, event and
+// dashboard.
+func defaultTagsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				tagsKey: {
+					Type:     schema.TypeSet,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// parseDefaultTags returns the tags of the default_tags block of the
+// provider.
+func parseDefaultTags(d *schema.ResourceData) []string {
+	blocks := d.Get(defaultTagsKey).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	return parseStrArr(blocks[0].(map[string]interface{})[tagsKey].(*schema.Set).List())
+}
+
+// tagsAllSchema is the schema of the tags_all attribute of the resources
+// with tags, holding their tags merged with the default tags of the
+// provider.
+func tagsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Computed: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// mergeTags returns the sorted union of tags and defaultTags.
+func mergeTags(tags, defaultTags []string) []string {
+	if len(tags)+len(defaultTags) == 0 {
+		return nil
+	}
+	merged := make([]string, 0, len(tags)+len(defaultTags))
+	merged = append(merged, tags...)
+	merged = append(merged, defaultTags...)
+	slices.Sort(merged)
+	return slices.Compact(merged)
+}
+
+// resourceTags returns the tags to send to Wavefront for the resource: its
+// tags merged with the default tags of the provider.
+func resourceTags(d *schema.ResourceData, meta interface{}) []string {
+	return mergeTags(decodeResourceTags(d.Get(tagsKey)), meta.(*wavefrontClient).defaultTags)
+}
+
+// setResourceTags sets tags_all to the tags of the resource in Wavefront, and
+// tags to the same tags but the default tags of the provider, unless they're
+// set on the resource too.
+func setResourceTags(d *schema.ResourceData, meta interface{}, tags []string) error {
+	configured := decodeResourceTags(d.Get(tagsKey))
+	defaultTags := meta.(*wavefrontClient).defaultTags
+	own := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !slices.Contains(defaultTags, tag) || slices.Contains(configured, tag) {
+			own = append(own, tag)
+		}
+	}
+	if err := d.Set(tagsKey, own); err != nil {
+		return err
+	}
+	return d.Set(tagsAllKey, tags)
+}
+
+// customizeDiffTagsAll plans tags_all as the tags of the resource merged with
+// the default tags of the provider, so that changes of the default tags
+// update the resource.
+func customizeDiffTagsAll(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown(tagsKey) {
+		return diff.SetNewComputed(tagsAllKey)
+	}
+	merged := mergeTags(decodeResourceTags(diff.Get(tagsKey)), meta.(*wavefrontClient).defaultTags)
+	current := decodeResourceTags(diff.Get(tagsAllKey))
+	slices.Sort(current)
+	if slices.Equal(merged, current) {
+		return nil
+	}
+	return diff.SetNew(tagsAllKey, merged)
+}
+
+// decodeResourceTags decodes tags, held in a set or a list.
+func decodeResourceTags(raw interface{}) []string {
+	if set, ok := raw.(*schema.Set); ok {
+		return parseStrArr(set.List())
+	}
+	return parseStrArr(raw)
+}
@@ -0,0 +1,142 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTags(t *testing.T) {
+	assert.Nil(t, mergeTags(nil, nil))
+	assert.Equal(t, []string{"a", "b"}, mergeTags([]string{"b", "a"}, nil))
+	assert.Equal(t, []string{"a", "b", "team.sre"}, mergeTags([]string{"b", "team.sre"}, []string{"team.sre", "a"}))
+}
+
+// testDefaultTagsProvider returns the provider configured against the fake
+// Wavefront API with default tags.
+func testDefaultTagsProvider(t *testing.T, defaultTags ...interface{}) *schema.Provider {
+	t.Helper()
+	return fakeAPIProviderWithConfig(t, map[string]interface{}{
+		defaultTagsKey: []interface{}{map[string]interface{}{tagsKey: defaultTags}},
+	})
+}
+
+func TestDefaultTags_DerivedMetric(t *testing.T) {
+	provider := testDefaultTagsProvider(t, "team.sre", "managed-by.terraform")
+	client := provider.Meta().(*wavefrontClient).client
+	assert.ElementsMatch(t, []string{"team.sre", "managed-by.terraform"}, provider.Meta().(*wavefrontClient).defaultTags)
+
+	derivedMetric := resourceDerivedMetric()
+	d := schema.TestResourceDataRaw(t, derivedMetric.Schema, map[string]interface{}{
+		"name":    "Default Tags",
+		"query":   "ts(cpu.usage)",
+		"minutes": 5,
+		tagsKey:   []interface{}{"team.sre", "app.checkout"},
+	})
+	diags := derivedMetric.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	id := d.Id()
+	dm := &wavefront.DerivedMetric{ID: &id}
+	require.NoError(t, client.DerivedMetrics().Get(dm))
+	assert.ElementsMatch(t, []string{"app.checkout", "managed-by.terraform", "team.sre"}, dm.Tags.CustomerTags)
+	assert.ElementsMatch(t, []string{"app.checkout", "team.sre"}, getStringSlice(d, tagsKey),
+		"default tags must only be kept in tags when set on the resource too")
+	assert.ElementsMatch(t, []string{"app.checkout", "managed-by.terraform", "team.sre"}, getStringSlice(d, tagsAllKey))
+
+	imported := derivedMetric.TestResourceData()
+	imported.SetId(id)
+	diags = derivedMetric.ReadContext(context.Background(), imported, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"app.checkout"}, getStringSlice(imported, tagsKey))
+}
+
+func TestDefaultTags_Diff(t *testing.T) {
+	provider := testDefaultTagsProvider(t, "team.sre", "env.prod")
+	derivedMetric := resourceDerivedMetric()
+	state := &terraform.InstanceState{
+		ID: "1",
+		Attributes: map[string]string{
+			"id":         "1",
+			"name":       "Default Tags",
+			"query":      "ts(cpu.usage)",
+			"minutes":    "5",
+			"tags.#":     "1",
+			"tags.0":     "team.sre",
+			"tags_all.#": "1",
+			"tags_all.0": "team.sre",
+		},
+	}
+	config := func(tags ...interface{}) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":    "Default Tags",
+			"query":   "ts(cpu.usage)",
+			"minutes": 5,
+			tagsKey:   tags,
+		})
+	}
+
+	// a default tag added to the provider
+	diff, err := derivedMetric.Diff(context.Background(), state, config("team.sre"), provider.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Equal(t, "2", diff.Attributes["tags_all.#"].New)
+
+	// the same tags, some set at both levels
+	state.Attributes["tags_all.#"] = "2"
+	state.Attributes["tags_all.1"] = "env.prod"
+	diff, err = derivedMetric.Diff(context.Background(), state, config("team.sre"), provider.Meta())
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), "%v", diff)
+}
+
+func TestAccWavefrontDerivedMetric_DefaultTags(t *testing.T) {
+	resourceName := "wavefront_derived_metric.derived"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontDerivedMetricDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontDerivedMetricDefaultTags(`"managed-by.terraform"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.#", "3"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "tags_all.*", "managed-by.terraform"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontDerivedMetricDefaultTags(`"managed-by.terraform", "env.prod"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "tags.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.#", "4"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "tags_all.*", "env.prod"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontDerivedMetricDefaultTags(defaultTags string) string {
+	return fmt.Sprintf(`
+provider "wavefront" {
+  default_tags {
+    tags = ["team.sre", %s]
+  }
+}
+
+resource "wavefront_derived_metric" "derived" {
+  name    = "Terraform Test Derived Metric Default Tags"
+  query   = "aliasMetric(5, \"cpu.usage\")"
+  minutes = 5
+  tags    = ["team.sre", "app.checkout"]
+}
+`, defaultTags)
+}
@@ -0,0 +1,511 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// ExportOptions configures Export.
+type ExportOptions struct {
+	// Dir is the directory the .tf files are written to.
+	Dir string
+
+	// Types are the resource types to export, such as wavefront_alert. All
+	// of ExportTypes are exported when it's empty.
+	Types []string
+
+	// Provider holds arguments of the provider block, such as address and
+	// profile. The others are read from the environment, as they are by
+	// Terraform.
+	Provider map[string]interface{}
+}
+
+// exportType is a resource type Export walks, with how to find its
+// resources with the search API.
+type exportType struct {
+	resource   string
+	searchType string
+	idKey      string
+	nameKey    string
+
+	// skip returns whether a search result isn't exported, such as the
+	// system user groups, which can't be managed.
+	skip func(item map[string]interface{}) bool
+
+	// omit returns the attributes which must not be written, such as the
+	// condition of threshold alerts, which Wavefront derives.
+	omit func(values map[string]interface{}) []string
+}
+
+// exportTypes are in the order of their files, referenced resources first.
+var exportTypes = []exportType{
+	{resource: "wavefront_alert_target", searchType: "notificant", idKey: "id", nameKey: "title"},
+	{resource: "wavefront_user_group", searchType: "usergroup", idKey: "id", nameKey: "name", skip: isSystemUserGroup},
+	{resource: "wavefront_user", searchType: "user", idKey: "identifier", nameKey: "identifier", omit: omitUserCustomer},
+	{resource: "wavefront_role", searchType: "role", idKey: "id", nameKey: "name"},
+	{resource: "wavefront_dashboard", searchType: "dashboard", idKey: "id", nameKey: "name", skip: isSystemOwned},
+	{resource: "wavefront_alert", searchType: "alert", idKey: "id", nameKey: "name", skip: isSystemOwned, omit: omitThresholdAlertAttributes},
+	{resource: "wavefront_derived_metric", searchType: "derivedmetric", idKey: "id", nameKey: "name"},
+	{resource: "wavefront_maintenance_window", searchType: "maintenancewindow", idKey: "id", nameKey: "title"},
+	{resource: "wavefront_external_link", searchType: "extlink", idKey: "id", nameKey: "name"},
+}
+
+// ExportTypes returns the resource types Export supports.
+func ExportTypes() []string {
+	types := make([]string, 0, len(exportTypes))
+	for _, t := range exportTypes {
+		types = append(types, t.resource)
+	}
+	return types
+}
+
+// exportReference describes an attribute holding the IDs of other
+// resources. With alertTargets set, the attribute is a comma separated list
+// of alert targets, in which target:<id> refers to a wavefront_alert_target.
+type exportReference struct {
+	types        []string
+	alertTargets bool
+}
+
+// exportReferences are the references between resources, by resource type
+// and attribute. The attributes of nested blocks are dotted paths.
+var exportReferences = map[string]map[string]exportReference{
+	"wavefront_alert": {
+		targetKey:           {alertTargets: true},
+		thresholdTargetsKey: {alertTargets: true},
+		canViewKey:          {types: []string{"wavefront_user_group", "wavefront_user"}},
+		canModifyKey:        {types: []string{"wavefront_user_group", "wavefront_user"}},
+		alertTriageDashboardsKey + "." + dashboardIDKey: {types: []string{"wavefront_dashboard"}},
+	},
+	"wavefront_dashboard": {
+		"can_view":   {types: []string{"wavefront_user_group", "wavefront_user"}},
+		"can_modify": {types: []string{"wavefront_user_group", "wavefront_user"}},
+	},
+	"wavefront_user": {
+		"user_groups": {types: []string{"wavefront_user_group"}},
+	},
+	"wavefront_role": {
+		"assignees": {types: []string{"wavefront_user_group", "wavefront_user"}},
+	},
+}
+
+func isSystemUserGroup(item map[string]interface{}) bool {
+	properties, _ := item["properties"].(map[string]interface{})
+	nameEditable, ok := properties["nameEditable"].(bool)
+	return ok && !nameEditable
+}
+
+func isSystemOwned(item map[string]interface{}) bool {
+	systemOwned, _ := item["systemOwned"].(bool)
+	return systemOwned
+}
+
+func omitThresholdAlertAttributes(values map[string]interface{}) []string {
+	if strings.EqualFold(fmt.Sprint(values[alertTypeKey]), "THRESHOLD") {
+		return []string{conditionKey, severityKey}
+	}
+	return nil
+}
+
+// omitUserCustomer omits the customer of users, which is their tenant.
+func omitUserCustomer(map[string]interface{}) []string {
+	return []string{"customer"}
+}
+
+// exportedResource is a resource found by Export.
+type exportedResource struct {
+	id   string
+	name string
+}
+
+// Export writes the resources of a Wavefront tenant to .tf files, one per
+// resource type found, with import blocks for all of them in imports.tf. The
+// resources are read as the provider reads them, and the IDs of other
+// exported resources, such as the alert targets of alerts, are replaced by
+// references to them.
+func Export(ctx context.Context, options ExportOptions) error {
+	types, err := selectExportTypes(options.Types)
+	if err != nil {
+		return err
+	}
+	provider := Provider()
+	if diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(options.Provider)); diags.HasError() {
+		return fmt.Errorf("failed to configure the provider, %s", diagsError(diags))
+	}
+	meta := provider.Meta()
+
+	files := map[string]*hclwrite.File{}
+	fileNames := []string{"imports.tf"}
+	for _, t := range types {
+		fileNames = append(fileNames, strings.TrimPrefix(t.resource, "wavefront_")+".tf")
+	}
+	for _, name := range fileNames {
+		path := filepath.Join(options.Dir, name)
+		if _, err = os.Stat(path); err == nil {
+			return fmt.Errorf("refusing to overwrite %s", path)
+		}
+		files[name] = hclwrite.NewEmptyFile()
+	}
+
+	// Find every resource first, so that references can be resolved
+	// whichever file they're in.
+	index := map[string]map[string]string{}
+	found := map[string][]exportedResource{}
+	for _, t := range types {
+		if found[t.resource], err = findExportedResources(ctx, t, meta); err != nil {
+			return err
+		}
+		index[t.resource] = map[string]string{}
+		for _, r := range found[t.resource] {
+			index[t.resource][r.id] = r.name
+		}
+	}
+
+	imports := files["imports.tf"].Body()
+	importCount := 0
+	for i, t := range types {
+		resource := provider.ResourcesMap[t.resource]
+		body := files[fileNames[i+1]].Body()
+		w := &exportWriter{resourceType: t.resource, index: index}
+		count := 0
+		for _, r := range found[t.resource] {
+			d := resource.Data(nil)
+			d.SetId(r.id)
+			if diags := resource.ReadContext(ctx, d, meta); diags.HasError() {
+				return fmt.Errorf("failed to read %s %s, %s", t.resource, r.id, diagsError(diags))
+			}
+			if d.Id() == "" {
+				continue
+			}
+			values := map[string]interface{}{}
+			for key := range resource.Schema {
+				values[key] = d.Get(key)
+			}
+			if t.omit != nil {
+				for _, key := range t.omit(values) {
+					delete(values, key)
+				}
+			}
+
+			if count > 0 {
+				body.AppendNewline()
+			}
+			if importCount > 0 {
+				imports.AppendNewline()
+			}
+			block := body.AppendNewBlock("resource", []string{t.resource, r.name})
+			w.writeBody(block.Body(), "", resource.Schema, values)
+
+			block = imports.AppendNewBlock("import", nil)
+			block.Body().SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: t.resource},
+				hcl.TraverseAttr{Name: r.name},
+			})
+			block.Body().SetAttributeValue("id", cty.StringVal(r.id))
+			count++
+			importCount++
+		}
+		log.Printf("[INFO] exported %d %s", count, t.resource)
+	}
+
+	for _, name := range fileNames {
+		if len(files[name].Body().Blocks()) == 0 {
+			continue
+		}
+		path := filepath.Join(options.Dir, name)
+		if err = os.WriteFile(path, hclwrite.Format(files[name].Bytes()), 0o644); err != nil {
+			return fmt.Errorf("failed to write %s, %s", path, err)
+		}
+	}
+	return nil
+}
+
+func selectExportTypes(names []string) ([]exportType, error) {
+	if len(names) == 0 {
+		return exportTypes, nil
+	}
+	selected := map[string]bool{}
+	for _, name := range names {
+		selected[name] = true
+	}
+	var types []exportType
+	for _, t := range exportTypes {
+		if selected[t.resource] {
+			types = append(types, t)
+			delete(selected, t.resource)
+		}
+	}
+	for name := range selected {
+		return nil, fmt.Errorf("unsupported resource type %s, expected one of %s", name, strings.Join(ExportTypes(), ", "))
+	}
+	return types, nil
+}
+
+// diagsError returns the errors of diags as a single error.
+func diagsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		messages = append(messages, message)
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+// findExportedResources searches all the resources of type t, naming them
+// after their names, made unique.
+func findExportedResources(ctx context.Context, t exportType, meta interface{}) ([]exportedResource, error) {
+	raw, err := searchAll(ctx, 0, 0, t.searchType, nil, nil, meta)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]interface{}
+	if err = json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("error parsing Wavefront %s search results, %s", t.searchType, err)
+	}
+
+	var resources []exportedResource
+	for _, item := range items {
+		if t.skip != nil && t.skip(item) {
+			continue
+		}
+		id := fmt.Sprint(item[t.idKey])
+		name, _ := item[t.nameKey].(string)
+		resources = append(resources, exportedResource{id: id, name: name})
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		if resources[i].name != resources[j].name {
+			return resources[i].name < resources[j].name
+		}
+		return resources[i].id < resources[j].id
+	})
+
+	used := map[string]bool{}
+	for i := range resources {
+		base := exportName(resources[i].name, strings.TrimPrefix(t.resource, "wavefront_"))
+		name := base
+		for n := 2; used[name]; n++ {
+			name = base + "_" + strconv.Itoa(n)
+		}
+		used[name] = true
+		resources[i].name = name
+	}
+	return resources, nil
+}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// exportName returns the name of a resource block for a resource named name:
+// its letters and digits, lowercased and separated by underscores, prefixed
+// by kind when it doesn't start with a letter.
+func exportName(name, kind string) string {
+	slug := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if slug == "" {
+		return kind
+	}
+	if slug[0] < 'a' || slug[0] > 'z' {
+		return kind + "_" + slug
+	}
+	return slug
+}
+
+// exportWriter writes the attributes of resources of a type, replacing the
+// IDs of the resources in index, by type and ID, with references to them.
+type exportWriter struct {
+	resourceType string
+	index        map[string]map[string]string
+}
+
+// writeBody writes the attributes and blocks of values, whose schema is
+// schemaMap, to body: the required attributes and the optional ones set to
+// other than their default, then the blocks, each in alphabetical order.
+func (w *exportWriter) writeBody(body *hclwrite.Body, path string, schemaMap map[string]*schema.Schema, values map[string]interface{}) {
+	var attributes, blocks []string
+	for key, s := range schemaMap {
+		value, ok := values[key]
+		switch {
+		case !ok || (!s.Required && !s.Optional) || s.Deprecated != "":
+		case isBlock(s):
+			if len(exportList(value)) > 0 {
+				blocks = append(blocks, key)
+			}
+		case s.Required:
+			attributes = append(attributes, key)
+		case !isEmptyValue(value) && !(s.Default != nil && fmt.Sprint(s.Default) == fmt.Sprint(value)):
+			attributes = append(attributes, key)
+		}
+	}
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	for _, key := range attributes {
+		body.SetAttributeRaw(key, w.tokens(path+key, values[key]))
+	}
+	for _, key := range blocks {
+		elem := schemaMap[key].Elem.(*schema.Resource)
+		for _, v := range exportList(values[key]) {
+			nested, _ := v.(map[string]interface{})
+			block := body.AppendNewBlock(key, nil)
+			w.writeBody(block.Body(), path+key+".", elem.Schema, nested)
+		}
+	}
+}
+
+func isBlock(s *schema.Schema) bool {
+	_, ok := s.Elem.(*schema.Resource)
+	return ok && (s.Type == schema.TypeList || s.Type == schema.TypeSet)
+}
+
+// exportList returns the elements of a list or set, sets sorted by their
+// string form so that exports are reproducible.
+func exportList(value interface{}) []interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		return v
+	case *schema.Set:
+		list := v.List()
+		sort.SliceStable(list, func(i, j int) bool {
+			return fmt.Sprint(list[i]) < fmt.Sprint(list[j])
+		})
+		return list
+	}
+	return nil
+}
+
+func isEmptyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case int:
+		return v == 0
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return len(exportList(value)) == 0
+}
+
+// tokens returns the expression for value, the value of the attribute at
+// path.
+func (w *exportWriter) tokens(path string, value interface{}) hclwrite.Tokens {
+	switch v := value.(type) {
+	case string:
+		return w.stringTokens(path, v)
+	case int:
+		return hclwrite.TokensForValue(cty.NumberIntVal(int64(v)))
+	case float64:
+		return hclwrite.TokensForValue(cty.NumberFloatVal(v))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		attrs := make([]hclwrite.ObjectAttrTokens, 0, len(keys))
+		for _, key := range keys {
+			name := hclwrite.TokensForValue(cty.StringVal(key))
+			if hclsyntax.ValidIdentifier(key) {
+				name = hclwrite.TokensForIdentifier(key)
+			}
+			attrs = append(attrs, hclwrite.ObjectAttrTokens{Name: name, Value: w.tokens(path, v[key])})
+		}
+		return hclwrite.TokensForObject(attrs)
+	}
+	list := exportList(value)
+	elems := make([]hclwrite.Tokens, 0, len(list))
+	for _, elem := range list {
+		elems = append(elems, w.tokens(path, elem))
+	}
+	return hclwrite.TokensForTuple(elems)
+}
+
+// stringTokens returns the expression for the string value of the attribute
+// at path, which is a reference, or a template of references for lists of
+// alert targets, when it refers to exported resources.
+func (w *exportWriter) stringTokens(path, value string) hclwrite.Tokens {
+	reference, ok := exportReferences[w.resourceType][path]
+	if !ok {
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	}
+	if !reference.alertTargets {
+		for _, t := range reference.types {
+			if name, found := w.index[t][value]; found {
+				return hclwrite.TokensForTraversal(resourceIDTraversal(t, name))
+			}
+		}
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	}
+
+	// Build "a@example.com,target:${wavefront_alert_target.x.id}".
+	tokens := hclwrite.Tokens{{Type: hclsyntax.TokenOQuote, Bytes: []byte(`"`)}}
+	literal := ""
+	referenced := false
+	for i, entry := range strings.Split(value, ",") {
+		if i > 0 {
+			literal += ","
+		}
+		name, found := w.index["wavefront_alert_target"][strings.TrimPrefix(entry, "target:")]
+		if !strings.HasPrefix(entry, "target:") || !found {
+			literal += entry
+			continue
+		}
+		referenced = true
+		tokens = append(tokens, quotedLiteralTokens(literal+"target:")...)
+		literal = ""
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateInterp, Bytes: []byte("${")})
+		tokens = append(tokens, hclwrite.TokensForTraversal(resourceIDTraversal("wavefront_alert_target", name))...)
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenTemplateSeqEnd, Bytes: []byte("}")})
+	}
+	if !referenced {
+		return hclwrite.TokensForValue(cty.StringVal(value))
+	}
+	tokens = append(tokens, quotedLiteralTokens(literal)...)
+	return append(tokens, &hclwrite.Token{Type: hclsyntax.TokenCQuote, Bytes: []byte(`"`)})
+}
+
+// quotedLiteralTokens returns the tokens of s inside a quoted template,
+// escaped.
+func quotedLiteralTokens(s string) hclwrite.Tokens {
+	if s == "" {
+		return nil
+	}
+	quoted := hclwrite.TokensForValue(cty.StringVal(s))
+	return quoted[1 : len(quoted)-1]
+}
+
+func resourceIDTraversal(resourceType, name string) hcl.Traversal {
+	return hcl.Traversal{
+		hcl.TraverseRoot{Name: resourceType},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: "id"},
+	}
+}
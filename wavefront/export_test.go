@@ -0,0 +1,138 @@
+package wavefront
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/fakeapi"
+)
+
+// assertAttribute asserts that content has the attribute name set to the
+// expression value, however it's aligned.
+func assertAttribute(t *testing.T, content, name, value string) {
+	pattern := `(?m)^\s*` + regexp.QuoteMeta(name) + `\s+= ` + regexp.QuoteMeta(value) + `$`
+	assert.Regexp(t, pattern, content)
+}
+
+func TestExportName(t *testing.T) {
+	cases := map[string]string{
+		"CPU Usage > 90%":  "cpu_usage_90",
+		"jane@example.com": "jane_example_com",
+		"5xx errors":       "alert_5xx_errors",
+		"":                 "alert",
+		"---":              "alert",
+	}
+	for name, expected := range cases {
+		assert.Equal(t, expected, exportName(name, "alert"), name)
+	}
+}
+
+func TestSelectExportTypes(t *testing.T) {
+	types, err := selectExportTypes(nil)
+	require.NoError(t, err)
+	assert.Len(t, types, len(exportTypes))
+
+	types, err = selectExportTypes([]string{"wavefront_alert", "wavefront_alert_target"})
+	require.NoError(t, err)
+	require.Len(t, types, 2)
+	assert.Equal(t, "wavefront_alert_target", types[0].resource, "types must be in dependency order")
+
+	_, err = selectExportTypes([]string{"wavefront_event"})
+	assert.ErrorContains(t, err, "unsupported resource type wavefront_event")
+}
+
+func TestExport(t *testing.T) {
+	server := fakeapi.NewServer()
+	t.Cleanup(server.Close)
+	for _, name := range testProviderEnv {
+		t.Setenv(name, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.Address(), Token: fakeapi.Token})
+	require.NoError(t, err)
+
+	target := &wavefront.Target{
+		Title:     "On call",
+		Method:    "EMAIL",
+		Recipient: "oncall@example.com",
+		Template:  "{}",
+		Triggers:  []string{"ALERT_OPENED"},
+	}
+	require.NoError(t, client.Targets().Create(target))
+	group := &wavefront.UserGroup{Name: "SRE"}
+	require.NoError(t, client.UserGroups().Create(group))
+	require.NoError(t, client.Users().Create(&wavefront.NewUserRequest{
+		EmailAddress: "jane@example.com",
+		Groups:       wavefront.UserGroupsWrapper{UserGroups: []wavefront.UserGroup{{ID: group.ID}}},
+	}, &wavefront.User{}, false))
+	require.NoError(t, client.Alerts().Create(&wavefront.Alert{
+		Name:      "CPU usage",
+		Target:    "pd:0123456789abcdef,target:" + *target.ID,
+		Condition: `ts(cpu.usage, env="${env}") > 90`,
+		Minutes:   5,
+		Severity:  "WARN",
+		Tags:      []string{"infra"},
+	}))
+	require.NoError(t, client.DerivedMetrics().Create(&wavefront.DerivedMetric{
+		Name:    "Requests",
+		Query:   "aliasMetric(rate(ts(requests.count)), \"requests.rate\")",
+		Minutes: 5,
+	}))
+
+	dir := t.TempDir()
+	err = Export(context.Background(), ExportOptions{
+		Dir: dir,
+		Provider: map[string]interface{}{
+			"address": server.Address(),
+			"token":   fakeapi.Token,
+		},
+		Types: []string{"wavefront_alert_target", "wavefront_user_group", "wavefront_user", "wavefront_alert",
+			"wavefront_derived_metric"},
+	})
+	require.NoError(t, err)
+
+	parser := hclparse.NewParser()
+	read := func(name string) string {
+		path := filepath.Join(dir, name)
+		content, readErr := os.ReadFile(path)
+		require.NoError(t, readErr)
+		_, diags := parser.ParseHCL(content, path)
+		require.False(t, diags.HasErrors(), "%s must be valid HCL: %s", name, diags)
+		return string(content)
+	}
+
+	alerts := read("alert.tf")
+	assert.Contains(t, alerts, `resource "wavefront_alert" "cpu_usage" {`)
+	assertAttribute(t, alerts, "target", `"pd:0123456789abcdef,target:${wavefront_alert_target.on_call.id}"`)
+	assertAttribute(t, alerts, "condition", `"ts(cpu.usage, env=\"$${env}\") > 90"`)
+	assert.NotContains(t, alerts, "process_rate_minutes", "defaults must be omitted")
+
+	users := read("user.tf")
+	assert.Contains(t, users, `resource "wavefront_user" "jane_example_com" {`)
+	assertAttribute(t, users, "user_groups", "[wavefront_user_group.sre.id]")
+	assert.NotContains(t, users, "customer")
+
+	groups := read("user_group.tf")
+	assert.Contains(t, groups, `resource "wavefront_user_group" "sre" {`)
+	assert.NotContains(t, groups, "Everyone", "system groups must not be exported")
+
+	assertAttribute(t, read("derived_metric.tf"), "query", `"aliasMetric(rate(ts(requests.count)), \"requests.rate\")"`)
+	assertAttribute(t, read("alert_target.tf"), "recipient", `"oncall@example.com"`)
+
+	imports := read("imports.tf")
+	assert.Contains(t, imports, "import {\n  to = wavefront_alert_target.on_call\n  id = \""+*target.ID+"\"\n}")
+	assert.Contains(t, imports, "to = wavefront_user.jane_example_com\n  id = \"jane@example.com\"")
+
+	err = Export(context.Background(), ExportOptions{
+		Dir:      dir,
+		Provider: map[string]interface{}{"address": server.Address(), "token": fakeapi.Token},
+	})
+	assert.ErrorContains(t, err, "refusing to overwrite")
+}
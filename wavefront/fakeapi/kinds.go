@@ -0,0 +1,329 @@
+package fakeapi
+
+const (
+	alertKind             = "alert"
+	cloudIntegrationKind  = "cloudintegration"
+	dashboardKind         = "dashboard"
+	derivedMetricKind     = "derivedmetric"
+	eventKind             = "event"
+	externalLinkKind      = "extlink"
+	ingestionPolicyKind   = "ingestionpolicy"
+	maintenanceWindowKind = "maintenancewindow"
+	roleKind              = "role"
+	serviceAccountKind    = "serviceaccount"
+	targetKind            = "notificant"
+	userGroupKind         = "usergroup"
+	userKind              = "user"
+
+	// accountKind is referenced by fields holding users or service accounts.
+	accountKind = "account"
+)
+
+// kind describes how the entities of a collection are served.
+type kind struct {
+	// name is the type of the entities in the search API.
+	name string
+	// path is the path of the collection, relative to /api/v2/.
+	path string
+	// deletePath is the path entities are deleted at, if not path.
+	deletePath string
+	// idKey is the field holding the id of the entities.
+	idKey string
+	// idFrom is the field the id of new entities is taken from, if it's not
+	// assigned by Wavefront.
+	idFrom string
+	// numericIDs is whether Wavefront assigns numeric ids, rather than UUIDs.
+	numericIDs bool
+	// required are the fields a new entity must have.
+	required []string
+	// references maps the fields referencing other entities to their kind.
+	references map[string]string
+	// derived are the fields computed by Wavefront, such as from other
+	// entities, which are ignored in requests.
+	derived []string
+	// preserved are the fields managed by other endpoints, which updates
+	// keep.
+	preserved []string
+	// expand returns the entity as it's served, if it differs from the way
+	// it's stored.
+	expand func(s *Server, e entity) entity
+	// defaults are the fields Wavefront sets on new entities missing them.
+	defaults entity
+	// directGet is whether the entities are got without the status and
+	// response stanzas.
+	directGet bool
+}
+
+// assignID returns the id of a new entity e, or "" if Wavefront assigns it.
+func (k *kind) assignID(e entity) string {
+	if k.idFrom == "" {
+		return ""
+	}
+	id, _ := e[k.idFrom].(string)
+	return id
+}
+
+var kinds = []*kind{
+	{name: alertKind, path: "alert", idKey: "id", numericIDs: true, required: []string{"name"}, preserved: []string{"acl", "snoozed"},
+		defaults: entity{"alertType": "CLASSIC"}},
+	{name: cloudIntegrationKind, path: "cloudintegration", idKey: "id", required: []string{"service"}},
+	{name: dashboardKind, path: "dashboard", idKey: "id", idFrom: "url", required: []string{"name", "url"}, preserved: []string{"acl"}},
+	{name: derivedMetricKind, path: "derivedmetric", idKey: "id", numericIDs: true, required: []string{"name", "query"}},
+	{name: eventKind, path: "event", idKey: "id", numericIDs: true, required: []string{"name"}},
+	{name: externalLinkKind, path: "extlink", idKey: "id", required: []string{"name", "template"}},
+	{
+		name:       ingestionPolicyKind,
+		path:       "usage/ingestionpolicy",
+		idKey:      "id",
+		required:   []string{"name"},
+		references: map[string]string{"accounts": accountKind, "groups": userGroupKind},
+		expand:     (*Server).expandIngestionPolicy,
+	},
+	{name: maintenanceWindowKind, path: "maintenancewindow", idKey: "id", required: []string{"title"}},
+	{
+		name:     roleKind,
+		path:     "role",
+		idKey:    "id",
+		required: []string{"name"},
+		derived: []string{"linkedGroupsCount", "linkedAccountsCount", "sampleLinkedGroups",
+			"sampleLinkedAccounts"},
+		expand: (*Server).expandRole,
+	},
+	{
+		name:       serviceAccountKind,
+		path:       "account/serviceaccount",
+		deletePath: "account",
+		idKey:      "identifier",
+		idFrom:     "identifier",
+		required:   []string{"identifier"},
+		references: map[string]string{"roles": roleKind, "userGroups": userGroupKind},
+		derived:    []string{"tokens", "ingestionPolicy"},
+		preserved:  []string{"tokens"},
+		expand:     (*Server).expandServiceAccount,
+	},
+	{name: targetKind, path: "notificant", idKey: "id", required: []string{"title"}},
+	{
+		name:      userGroupKind,
+		path:      "usergroup",
+		idKey:     "id",
+		required:  []string{"name"},
+		derived:   []string{"Roles", "roles", "users", "userCount", "properties"},
+		preserved: []string{"roles", "properties"},
+		defaults: entity{"properties": entity{
+			"nameEditable":        true,
+			"permissionsEditable": true,
+			"usersEditable":       true,
+			"rolesEditable":       true,
+		}},
+		expand: (*Server).expandUserGroup,
+	},
+	{
+		name:       userKind,
+		path:       "user",
+		idKey:      "identifier",
+		idFrom:     "emailAddress",
+		required:   []string{"emailAddress"},
+		references: map[string]string{"userGroups": userGroupKind},
+		derived:    []string{"emailAddress", "credential"},
+		preserved:  []string{"roles"},
+		expand:     (*Server).expandUser,
+		directGet:  true,
+	},
+}
+
+// refs returns the ids held by the field key of e.
+func refs(e entity, key string) []string {
+	return referenceIDs(e[key])
+}
+
+// accounts returns the users and service accounts, which are both accounts.
+func (s *Server) accounts() []entity {
+	return append(s.collections[userKind].list(), s.collections[serviceAccountKind].list()...)
+}
+
+func accountID(e entity) string {
+	id, _ := e["identifier"].(string)
+	return id
+}
+
+// account returns the user or service account with the given id.
+func (s *Server) account(id string) (entity, bool) {
+	if e, ok := s.collections[userKind].get(id); ok {
+		return e, true
+	}
+	return s.collections[serviceAccountKind].get(id)
+}
+
+func (s *Server) groupRefs(ids []string) []interface{} {
+	result := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		ref := entity{"id": id}
+		if g, ok := s.collections[userGroupKind].get(id); ok {
+			ref["name"] = g["name"]
+			ref["description"] = g["description"]
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+func (s *Server) roleRefs(ids []string) []interface{} {
+	result := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		ref := entity{"id": id}
+		if r, ok := s.collections[roleKind].get(id); ok {
+			ref["name"] = r["name"]
+			ref["description"] = r["description"]
+			ref["permissions"] = r["permissions"]
+		}
+		result = append(result, ref)
+	}
+	return result
+}
+
+func accountRefs(ids []string) []interface{} {
+	result := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, entity{"id": id, "name": id})
+	}
+	return result
+}
+
+func copyEntity(e entity) entity {
+	result := make(entity, len(e))
+	for k, v := range e {
+		result[k] = v
+	}
+	return result
+}
+
+func (s *Server) expandUser(e entity) entity {
+	result := copyEntity(e)
+	result["roles"] = s.roleRefs(refs(e, "roles"))
+	result["userGroups"] = s.groupRefs(refs(e, "userGroups"))
+	return result
+}
+
+// groupMembers returns the ids of the accounts in the group.
+func (s *Server) groupMembers(id string) []string {
+	var members []string
+	for _, a := range s.accounts() {
+		if contains(refs(a, "userGroups"), id) {
+			members = append(members, accountID(a))
+		}
+	}
+	return members
+}
+
+func (s *Server) expandUserGroup(e entity) entity {
+	result := copyEntity(e)
+	members := s.groupMembers(e["id"].(string))
+	result["users"] = toInterfaces(members)
+	result["userCount"] = len(members)
+	result["roles"] = s.roleRefs(refs(e, "roles"))
+	return result
+}
+
+func (s *Server) expandRole(e entity) entity {
+	id := e["id"].(string)
+	var groups, accounts []string
+	for _, g := range s.collections[userGroupKind].list() {
+		if contains(refs(g, "roles"), id) {
+			groups = append(groups, g["id"].(string))
+		}
+	}
+	for _, a := range s.accounts() {
+		if contains(refs(a, "roles"), id) {
+			accounts = append(accounts, accountID(a))
+		}
+	}
+	result := copyEntity(e)
+	result["linkedGroupsCount"] = len(groups)
+	result["linkedAccountsCount"] = len(accounts)
+	result["sampleLinkedGroups"] = s.groupRefs(groups)
+	result["sampleLinkedAccounts"] = toInterfaces(accounts)
+	return result
+}
+
+func (s *Server) expandServiceAccount(e entity) entity {
+	result := copyEntity(e)
+	result["roles"] = s.roleRefs(refs(e, "roles"))
+	result["userGroups"] = s.groupRefs(refs(e, "userGroups"))
+	if _, ok := e["tokens"]; !ok {
+		result["tokens"] = []interface{}{}
+	}
+	if id, _ := e["ingestionPolicyId"].(string); id != "" {
+		if p, ok := s.collections[ingestionPolicyKind].get(id); ok {
+			result["ingestionPolicy"] = s.expandIngestionPolicy(p)
+		}
+	}
+	return result
+}
+
+func (s *Server) expandIngestionPolicy(e entity) entity {
+	result := copyEntity(e)
+	result["accounts"] = accountRefs(refs(e, "accounts"))
+	result["groups"] = s.groupRefs(refs(e, "groups"))
+	return result
+}
+
+// expandMetricsPolicy returns the metrics policy with the accounts, groups
+// and roles of its rules expanded.
+func (s *Server) expandMetricsPolicy() entity {
+	result := copyEntity(s.metricsPolicy)
+	rules, _ := s.metricsPolicy["policyRules"].([]interface{})
+	expanded := make([]interface{}, 0, len(rules))
+	for _, r := range rules {
+		rule := copyEntity(r.(entity))
+		rule["accounts"] = accountRefs(refs(rule, "accounts"))
+		rule["userGroups"] = s.groupRefs(refs(rule, "userGroups"))
+		rule["roles"] = s.roleRefs(refs(rule, "roles"))
+		expanded = append(expanded, rule)
+	}
+	result["policyRules"] = expanded
+	return result
+}
+
+// removeReferences removes the references to the deleted entity id of kind
+// k, as Wavefront does.
+func (s *Server) removeReferences(k *kind, id string) {
+	target := k.name
+	if target == userKind || target == serviceAccountKind {
+		target = accountKind
+	}
+	for _, c := range s.collections {
+		for key, referenced := range c.kind.references {
+			if referenced != target {
+				continue
+			}
+			for _, e := range c.items {
+				if _, ok := e[key]; ok {
+					e[key] = toInterfaces(removeIDs(refs(e, key), id))
+				}
+			}
+		}
+	}
+	switch k.name {
+	case roleKind:
+		for _, e := range append(s.accounts(), s.collections[userGroupKind].list()...) {
+			if _, ok := e["roles"]; ok {
+				e["roles"] = toInterfaces(removeIDs(refs(e, "roles"), id))
+			}
+		}
+	case ingestionPolicyKind:
+		for _, e := range s.collections[serviceAccountKind].items {
+			if e["ingestionPolicyId"] == id {
+				delete(e, "ingestionPolicyId")
+			}
+		}
+	}
+	rules, _ := s.metricsPolicy["policyRules"].([]interface{})
+	for _, r := range rules {
+		rule := r.(entity)
+		for key, referenced := range map[string]string{"accounts": accountKind, "userGroups": userGroupKind, "roles": roleKind} {
+			if referenced == target {
+				rule[key] = toInterfaces(removeIDs(refs(rule, key), id))
+			}
+		}
+	}
+}
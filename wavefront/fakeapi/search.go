@@ -0,0 +1,168 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultSearchLimit = 100
+	maxSearchLimit     = 1000
+)
+
+type searchCondition struct {
+	Key            string `json:"key"`
+	Value          string `json:"value"`
+	MatchingMethod string `json:"matchingMethod"`
+	Negated        bool   `json:"negated"`
+}
+
+type searchRequest struct {
+	Conditions []searchCondition `json:"query"`
+	Limit      int               `json:"limit"`
+	Offset     int               `json:"offset"`
+	TimeRange  *struct {
+		Earliest int64 `json:"earliestStartTimeEpochMillis"`
+		Latest   int64 `json:"latestStartTimeEpochMillis"`
+	} `json:"timeRange"`
+}
+
+// search serves the search API of a kind: entities matching all the
+// conditions, in creation order, one page at a time.
+func (s *Server) search(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.collections[r.PathValue("type")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown search type "+r.PathValue("type"))
+		return
+	}
+	var request searchRequest
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	for _, condition := range request.Conditions {
+		if _, known := matchers[condition.MatchingMethod]; !known {
+			writeError(w, http.StatusBadRequest, "unknown matching method "+condition.MatchingMethod)
+			return
+		}
+	}
+	limit := request.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	var matches []interface{}
+	for _, e := range c.list() {
+		if !matchesAll(c.kind, e, request.Conditions) {
+			continue
+		}
+		if tr := request.TimeRange; tr != nil {
+			start := epochMillis(e["startTime"])
+			if start < tr.Earliest || (tr.Latest > 0 && start > tr.Latest) {
+				continue
+			}
+		}
+		matches = append(matches, s.expand(c.kind, e))
+	}
+
+	items := []interface{}{}
+	if request.Offset < len(matches) {
+		end := request.Offset + limit
+		if end > len(matches) {
+			end = len(matches)
+		}
+		items = matches[request.Offset:end]
+	}
+	writeResponse(w, entity{
+		"items":      items,
+		"offset":     request.Offset,
+		"limit":      limit,
+		"totalItems": len(matches),
+		"moreItems":  request.Offset+limit < len(matches),
+	})
+}
+
+var matchers = map[string]func(value, condition string) bool{
+	"EXACT": strings.EqualFold,
+	"CONTAINS": func(value, condition string) bool {
+		return strings.Contains(strings.ToLower(value), strings.ToLower(condition))
+	},
+	"STARTSWITH": func(value, condition string) bool {
+		return strings.HasPrefix(strings.ToLower(value), strings.ToLower(condition))
+	},
+	// TAGPATH matches the tag and the ones below it in the dotted hierarchy
+	// of tags, which "a.b" and "a.b.*" both select.
+	"TAGPATH": func(value, condition string) bool {
+		path := strings.ToLower(strings.TrimSuffix(condition, ".*"))
+		value = strings.ToLower(value)
+		return value == path || strings.HasPrefix(value, path+".")
+	},
+}
+
+func matchesAll(k *kind, e entity, conditions []searchCondition) bool {
+	for _, condition := range conditions {
+		if matches(k, e, condition) == condition.Negated {
+			return false
+		}
+	}
+	return true
+}
+
+// matches returns whether any of the values of the field of the condition
+// matches it.
+func matches(k *kind, e entity, condition searchCondition) bool {
+	key := condition.Key
+	if key == "id" {
+		key = k.idKey
+	}
+	match := matchers[condition.MatchingMethod]
+	for _, value := range searchValues(e[key]) {
+		if match(value, condition.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchValues returns the values of a field as strings. Lists yield each of
+// their values and tags, stored as {"customerTags": [...]}, yield each tag.
+func searchValues(v interface{}) []string {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, searchValues(item)...)
+		}
+		return values
+	case map[string]interface{}:
+		if tags, ok := v["customerTags"]; ok {
+			return searchValues(tags)
+		}
+		if id, ok := v["id"]; ok {
+			return searchValues(id)
+		}
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func epochMillis(v interface{}) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case json.Number:
+		n, _ := strconv.ParseInt(v.String(), 10, 64)
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,388 @@
+// Package fakeapi is an in-memory implementation of the Wavefront v2 REST API
+// endpoints used by the provider, so that its acceptance tests can run
+// without a Wavefront tenant.
+//
+// Entities are stored as the JSON objects they are created with, plus the
+// fields Wavefront assigns (ids, timestamps and the customer), and
+// relationships between users, groups, roles, service accounts and policies
+// are kept consistent as they are in Wavefront. Deleted entities are removed
+// rather than moved to the trash.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Token is the API token the server accepts.
+	Token = "fakeapi-token"
+	// Customer is the customer of the entities of the server.
+	Customer = "fakeapi"
+	// Updater is the account reported as the creator and updater of
+	// entities.
+	Updater = "fakeapi@example.com"
+
+	apiPrefix = "/api/v2/"
+)
+
+// entity is a Wavefront entity as it's stored and serialized.
+type entity = map[string]interface{}
+
+// collection holds the entities of a kind in creation order.
+type collection struct {
+	kind  *kind
+	order []string
+	items map[string]entity
+}
+
+func (c *collection) get(id string) (entity, bool) {
+	e, ok := c.items[id]
+	return e, ok
+}
+
+func (c *collection) put(id string, e entity) {
+	if _, ok := c.items[id]; !ok {
+		c.order = append(c.order, id)
+	}
+	c.items[id] = e
+}
+
+func (c *collection) remove(id string) {
+	delete(c.items, id)
+	for i, v := range c.order {
+		if v == id {
+			c.order = append(c.order[:i:i], c.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// list returns the entities of the collection in creation order.
+func (c *collection) list() []entity {
+	result := make([]entity, 0, len(c.order))
+	for _, id := range c.order {
+		result = append(result, c.items[id])
+	}
+	return result
+}
+
+// Server is a fake Wavefront API served over HTTP on a local port.
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	collections   map[string]*collection
+	metricsPolicy entity
+	externalIDs   map[string]bool
+	sequence      int
+	lastMillis    int64
+}
+
+// NewServer starts a fake Wavefront API, holding the "Everyone" user group
+// and the default metrics policy of a new tenant. It must be closed once
+// done with.
+func NewServer() *Server {
+	s := &Server{
+		collections: make(map[string]*collection),
+		externalIDs: make(map[string]bool),
+	}
+	mux := http.NewServeMux()
+	for _, k := range kinds {
+		s.collections[k.name] = &collection{kind: k, items: make(map[string]entity)}
+		s.handleKind(mux, k)
+	}
+	s.handleSpecial(mux)
+	s.seed()
+	s.Server = httptest.NewServer(s.authorize(mux))
+	return s
+}
+
+// seed creates the entities every Wavefront tenant starts with.
+func (s *Server) seed() {
+	everyone := s.create(s.collections[userGroupKind], "", entity{
+		"name":        "Everyone",
+		"description": "System group which contains all users",
+		"properties": entity{
+			"nameEditable":        false,
+			"permissionsEditable": true,
+			"usersEditable":       false,
+			"rolesEditable":       true,
+		},
+	})
+	s.metricsPolicy = entity{
+		"policyRules": []interface{}{
+			entity{
+				"name":        "Allow All Metrics",
+				"description": "Predefined policy rule. Allows access to all metrics.",
+				"prefixes":    []interface{}{"*"},
+				"tags":        []interface{}{},
+				"tagsAnded":   false,
+				"accessType":  "ALLOW",
+				"accounts":    []interface{}{},
+				"userGroups":  []interface{}{everyone["id"]},
+				"roles":       []interface{}{},
+			},
+		},
+		"customer":           Customer,
+		"updaterId":          Updater,
+		"updatedEpochMillis": s.now(),
+	}
+}
+
+// Address returns the address to configure the provider with.
+func (s *Server) Address() string {
+	return s.URL
+}
+
+func (s *Server) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+Token {
+			writeError(w, http.StatusUnauthorized, "invalid or missing API token")
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// now returns the current time in epoch milliseconds, increasing with each
+// call so that every update gets a distinct timestamp.
+func (s *Server) now() int64 {
+	millis := time.Now().UnixMilli()
+	if millis <= s.lastMillis {
+		millis = s.lastMillis + 1
+	}
+	s.lastMillis = millis
+	return millis
+}
+
+// newID returns an id in the format Wavefront uses for the kind.
+func (s *Server) newID(k *kind) string {
+	s.sequence++
+	if k.numericIDs {
+		return strconv.FormatInt(1700000000000+int64(s.sequence), 10)
+	}
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", s.sequence)
+}
+
+// create stores e as a new entity of c with the given id, or a new one if
+// it's empty, and the system fields.
+func (s *Server) create(c *collection, id string, e entity) entity {
+	if id == "" {
+		id = s.newID(c.kind)
+	}
+	e[c.kind.idKey] = id
+	for key, v := range c.kind.defaults {
+		if current, ok := e[key]; !ok || current == "" {
+			e[key] = v
+		}
+	}
+	now := s.now()
+	e["createdEpochMillis"] = now
+	e["updatedEpochMillis"] = now
+	e["creatorId"] = Updater
+	e["updaterId"] = Updater
+	e["customer"] = Customer
+	c.put(id, e)
+	return e
+}
+
+func (s *Server) handleKind(mux *http.ServeMux, k *kind) {
+	base := apiPrefix + k.path
+	mux.HandleFunc("POST "+base, func(w http.ResponseWriter, r *http.Request) {
+		var e entity
+		if !decodeBody(w, r, &e) {
+			return
+		}
+		c := s.collections[k.name]
+		for _, key := range k.required {
+			if v, _ := e[key].(string); v == "" {
+				writeError(w, http.StatusBadRequest, key+" is required")
+				return
+			}
+		}
+		id := k.assignID(e)
+		if _, ok := c.get(id); ok {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s %s already exists", k.name, id))
+			return
+		}
+		s.normalize(k, e)
+		s.writeEntity(w, k, s.create(c, id, e))
+	})
+	mux.HandleFunc("GET "+base+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		e, ok := s.find(w, k, r.PathValue("id"))
+		if !ok {
+			return
+		}
+		if k.directGet {
+			writeJSON(w, http.StatusOK, s.expand(k, e))
+			return
+		}
+		s.writeEntity(w, k, e)
+	})
+	mux.HandleFunc("PUT "+base+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		stored, ok := s.find(w, k, r.PathValue("id"))
+		if !ok {
+			return
+		}
+		var e entity
+		if !decodeBody(w, r, &e) {
+			return
+		}
+		s.normalize(k, e)
+		for _, key := range append([]string{k.idKey, "createdEpochMillis", "creatorId", "customer"}, k.preserved...) {
+			if v, ok := stored[key]; ok {
+				e[key] = v
+			} else {
+				delete(e, key)
+			}
+		}
+		e["updatedEpochMillis"] = s.now()
+		e["updaterId"] = Updater
+		s.collections[k.name].put(r.PathValue("id"), e)
+		s.writeEntity(w, k, e)
+	})
+	deletePath := base + "/{id}"
+	if k.deletePath != "" {
+		deletePath = apiPrefix + k.deletePath + "/{id}"
+	}
+	mux.HandleFunc("DELETE "+deletePath, func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		e, ok := s.find(w, k, id)
+		if !ok {
+			return
+		}
+		s.collections[k.name].remove(id)
+		s.removeReferences(k, id)
+		s.writeEntity(w, k, e)
+	})
+}
+
+// find returns the entity of kind k with the given id, or writes a 404.
+func (s *Server) find(w http.ResponseWriter, k *kind, id string) (entity, bool) {
+	e, ok := s.collections[k.name].get(id)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s not found", k.name, id))
+	}
+	return e, ok
+}
+
+// normalize replaces the references of e to other entities by their ids,
+// which is how Wavefront stores them regardless of how they're sent.
+func (s *Server) normalize(k *kind, e entity) {
+	for _, key := range k.references {
+		if v, ok := e[key]; ok {
+			e[key] = toInterfaces(referenceIDs(v))
+		}
+	}
+	for _, key := range k.derived {
+		delete(e, key)
+	}
+}
+
+// expand returns e as it's served.
+func (s *Server) expand(k *kind, e entity) entity {
+	if k.expand == nil {
+		return e
+	}
+	return k.expand(s, e)
+}
+
+func (s *Server) writeEntity(w http.ResponseWriter, k *kind, e entity) {
+	writeResponse(w, s.expand(k, e))
+}
+
+// decodeBody decodes the JSON body of r into v, or writes a 400.
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeResponse writes v wrapped in the status and response stanzas of
+// Wavefront responses.
+func writeResponse(w http.ResponseWriter, v interface{}) {
+	writeJSON(w, http.StatusOK, entity{
+		"status":   entity{"result": "OK", "message": "", "code": http.StatusOK},
+		"response": v,
+	})
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeJSON(w, code, entity{
+		"status": entity{"result": "ERROR", "message": message, "code": code},
+	})
+}
+
+// referenceIDs returns the ids of a list of references, which are either ids
+// or objects with an id.
+func referenceIDs(v interface{}) []string {
+	list, _ := v.([]interface{})
+	ids := make([]string, 0, len(list))
+	for _, item := range list {
+		switch item := item.(type) {
+		case string:
+			ids = append(ids, item)
+		case map[string]interface{}:
+			if id, ok := item["id"].(string); ok {
+				ids = append(ids, id)
+			}
+		}
+	}
+	return ids
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// addIDs returns ids with the missing ones of added appended.
+func addIDs(ids []string, added ...string) []string {
+	for _, id := range added {
+		if !contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// removeIDs returns ids without the ones of removed.
+func removeIDs(ids []string, removed ...string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !contains(removed, id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
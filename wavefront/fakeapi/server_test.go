@@ -0,0 +1,261 @@
+package fakeapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Server, *wavefront.Client) {
+	server := NewServer()
+	t.Cleanup(server.Close)
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.Address(), Token: Token})
+	require.NoError(t, err)
+	return server, client
+}
+
+func TestServer_RejectsUnknownToken(t *testing.T) {
+	server, _ := newTestClient(t)
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.Address(), Token: "other"})
+	require.NoError(t, err)
+
+	_, err = client.Alerts().Find(nil)
+	assert.ErrorContains(t, err, "401")
+}
+
+func TestServer_Alerts(t *testing.T) {
+	_, client := newTestClient(t)
+	alerts := client.Alerts()
+
+	alert := &wavefront.Alert{
+		Name:      "CPU",
+		Condition: "ts(cpu.usage) > 90",
+		Minutes:   5,
+		Severity:  "WARN",
+		Tags:      []string{"team.infra", "env.prod"},
+	}
+	require.NoError(t, alerts.Create(alert))
+	require.NotNil(t, alert.ID)
+	require.NoError(t, alerts.SetACL(*alert.ID, []string{"group-1"}, []string{"group-2"}))
+
+	alert.Minutes = 10
+	require.NoError(t, alerts.Update(alert))
+
+	got := &wavefront.Alert{ID: alert.ID}
+	require.NoError(t, alerts.Get(got))
+	assert.Equal(t, "CPU", got.Name)
+	assert.Equal(t, 10, got.Minutes)
+	assert.Equal(t, []string{"team.infra", "env.prod"}, got.Tags)
+	assert.Equal(t, []string{"group-1"}, got.ACL.CanView, "updates must keep the ACL")
+	assert.Equal(t, []string{"group-2"}, got.ACL.CanModify)
+
+	found, err := alerts.Find([]*wavefront.SearchCondition{{Key: "tags", Value: "team", MatchingMethod: "TAGPATH"}})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, *alert.ID, *found[0].ID)
+
+	require.NoError(t, alerts.Delete(alert, true))
+	err = alerts.Get(&wavefront.Alert{ID: got.ID})
+	assert.True(t, wavefront.NotFound(err), "deleted alerts must not be found, got %v", err)
+}
+
+func TestServer_Dashboards(t *testing.T) {
+	_, client := newTestClient(t)
+	dashboards := client.Dashboards()
+
+	dashboard := &wavefront.Dashboard{Name: "Hosts", Url: "hosts"}
+	require.NoError(t, dashboards.Create(dashboard))
+	assert.Equal(t, "hosts", dashboard.ID, "the url of dashboards is their id")
+	assert.Error(t, dashboards.Create(&wavefront.Dashboard{Name: "Duplicate", Url: "hosts"}))
+
+	require.NoError(t, dashboards.SetTags("hosts", []string{"infra"}))
+	got := &wavefront.Dashboard{ID: "hosts"}
+	require.NoError(t, dashboards.Get(got))
+	assert.Equal(t, []string{"infra"}, got.Tags)
+}
+
+func TestServer_UsersGroupsAndRoles(t *testing.T) {
+	_, client := newTestClient(t)
+
+	user := &wavefront.User{}
+	require.NoError(t, client.Users().Create(&wavefront.NewUserRequest{EmailAddress: "jane@example.com"}, user, false))
+	assert.Equal(t, "jane@example.com", *user.ID)
+	assert.Equal(t, Customer, user.Customer)
+
+	group := &wavefront.UserGroup{Name: "SRE"}
+	require.NoError(t, client.UserGroups().Create(group))
+	require.NoError(t, client.UserGroups().AddUsers(group.ID, &[]string{"jane@example.com"}))
+	assert.Error(t, client.UserGroups().AddUsers(group.ID, &[]string{"missing@example.com"}))
+
+	role := &wavefront.Role{Name: "Operators", Permissions: []string{"alerts_management"}}
+	require.NoError(t, client.Roles().Create(role))
+	require.NoError(t, client.Roles().AddAssignees([]string{*group.ID}, role))
+	assert.Equal(t, 1, role.LinkedGroupsCount)
+	require.NoError(t, client.Roles().GrantPermission("events_management", []*wavefront.Role{role}))
+
+	got := &wavefront.User{ID: user.ID}
+	require.NoError(t, client.Users().Get(got))
+	require.Len(t, got.Groups.UserGroups, 1)
+	assert.Equal(t, "SRE", got.Groups.UserGroups[0].Name)
+
+	gotGroup := &wavefront.UserGroup{ID: group.ID}
+	require.NoError(t, client.UserGroups().Get(gotGroup))
+	assert.Equal(t, []string{"jane@example.com"}, gotGroup.Users)
+	require.Len(t, gotGroup.Roles, 1)
+	assert.Equal(t, []string{"alerts_management", "events_management"}, gotGroup.Roles[0].Permissions)
+
+	found, err := client.Users().Find([]*wavefront.SearchCondition{
+		{Key: "id", Value: "jane@example.com", MatchingMethod: "EXACT"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	everyone, err := client.UserGroups().Find([]*wavefront.SearchCondition{
+		{Key: "name", Value: "Everyone", MatchingMethod: "EXACT"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, everyone, 1, "the Everyone group must exist")
+
+	require.NoError(t, client.UserGroups().Delete(group))
+	require.NoError(t, client.Users().Get(got))
+	assert.Empty(t, got.Groups.UserGroups, "deleting a group must remove its members from it")
+}
+
+func TestServer_ServiceAccountsAndPolicies(t *testing.T) {
+	_, client := newTestClient(t)
+
+	policy, err := client.IngestionPolicies().Create(&wavefront.IngestionPolicyRequest{Name: "Batch", Scope: "ACCOUNT"})
+	require.NoError(t, err)
+
+	account, err := client.ServiceAccounts().Create(&wavefront.ServiceAccountOptions{
+		ID:                "sa::ci",
+		Active:            true,
+		IngestionPolicyID: policy.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Batch", account.IngestionPolicy.Name)
+
+	tokens, err := client.Tokens().Create("sa::ci", &wavefront.TokenOptions{Name: "deploy"})
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	_, err = client.Tokens().Update("sa::ci", &wavefront.TokenOptions{ID: tokens[0].ID, Name: "release"})
+	require.NoError(t, err)
+
+	account.Description = "Continuous integration"
+	_, err = client.ServiceAccounts().Update(account.Options())
+	require.NoError(t, err)
+	account, err = client.ServiceAccounts().GetByID("sa::ci")
+	require.NoError(t, err)
+	assert.Equal(t, "Continuous integration", account.Description)
+	assert.Equal(t, []wavefront.Token{{ID: tokens[0].ID, Name: "release"}}, account.Tokens, "updates must keep the tokens")
+
+	require.NoError(t, client.Tokens().Delete("sa::ci", tokens[0].ID))
+	require.NoError(t, client.ServiceAccounts().DeleteByID("sa::ci"))
+	_, err = client.ServiceAccounts().GetByID("sa::ci")
+	assert.True(t, wavefront.NotFound(err))
+}
+
+func TestServer_MetricsPolicy(t *testing.T) {
+	_, client := newTestClient(t)
+	policies := client.MetricsPolicyAPI()
+
+	initial, err := policies.Get()
+	require.NoError(t, err)
+	require.Len(t, initial.PolicyRules, 1)
+	assert.Equal(t, "Everyone", initial.PolicyRules[0].UserGroups[0].Name)
+
+	updated, err := policies.Update(&wavefront.UpdateMetricsPolicyRequest{PolicyRules: []wavefront.PolicyRuleRequest{{
+		Name:         "Block secrets",
+		Prefixes:     []string{"secret.*"},
+		AccessType:   "BLOCK",
+		UserGroupIds: []string{initial.PolicyRules[0].UserGroups[0].ID},
+	}}})
+	require.NoError(t, err)
+	require.Len(t, updated.PolicyRules, 1)
+	assert.Equal(t, "Block secrets", updated.PolicyRules[0].Name)
+	assert.Greater(t, updated.UpdatedEpochMillis, initial.UpdatedEpochMillis)
+}
+
+func TestServer_CloudIntegrationsAndExternalIDs(t *testing.T) {
+	_, client := newTestClient(t)
+	integrations := client.CloudIntegrations()
+
+	externalID, err := integrations.CreateAwsExternalID()
+	require.NoError(t, err)
+	require.NoError(t, integrations.VerifyAwsExternalID(externalID))
+
+	integration := &wavefront.CloudIntegration{Name: "Metrics", Service: "CLOUDWATCH"}
+	require.NoError(t, integrations.Create(integration))
+	found, err := integrations.Find([]*wavefront.SearchCondition{
+		{Key: "id", Value: integration.Id, MatchingMethod: "EXACT"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, found, 1)
+
+	require.NoError(t, integrations.DeleteAwsExternalID(&externalID))
+	assert.Error(t, integrations.VerifyAwsExternalID("missing"))
+}
+
+// testSearch searches the server with the given conditions, returning the
+// names of the items of the page.
+func testSearch(t *testing.T, server *Server, typ string, request string) ([]string, bool) {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/api/v2/search/%s", server.URL, typ),
+		bytes.NewBufferString(request))
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+Token)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Response struct {
+			Items     []struct{ Name string }
+			MoreItems bool
+		}
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	names := make([]string, 0, len(body.Response.Items))
+	for _, item := range body.Response.Items {
+		names = append(names, item.Name)
+	}
+	return names, body.Response.MoreItems
+}
+
+func TestServer_Search(t *testing.T) {
+	server, client := newTestClient(t)
+	for _, name := range []string{"api-latency", "api-errors", "db-latency"} {
+		require.NoError(t, client.Alerts().Create(&wavefront.Alert{Name: name, Tags: []string{"team." + name[:2]}}))
+	}
+
+	cases := []struct {
+		request string
+		names   []string
+		more    bool
+	}{
+		{`{}`, []string{"api-latency", "api-errors", "db-latency"}, false},
+		{`{"limit": 2}`, []string{"api-latency", "api-errors"}, true},
+		{`{"limit": 2, "offset": 2}`, []string{"db-latency"}, false},
+		{`{"query": [{"key": "name", "value": "API", "matchingMethod": "STARTSWITH"}]}`,
+			[]string{"api-latency", "api-errors"}, false},
+		{`{"query": [{"key": "name", "value": "latency", "matchingMethod": "CONTAINS"}]}`,
+			[]string{"api-latency", "db-latency"}, false},
+		{`{"query": [{"key": "name", "value": "latency", "matchingMethod": "CONTAINS", "negated": true}]}`,
+			[]string{"api-errors"}, false},
+		{`{"query": [{"key": "tags", "value": "team.db", "matchingMethod": "EXACT"}]}`,
+			[]string{"db-latency"}, false},
+		{`{"query": [{"key": "name", "value": "db", "matchingMethod": "STARTSWITH"},
+			{"key": "tags", "value": "team.*", "matchingMethod": "TAGPATH"}]}`, []string{"db-latency"}, false},
+	}
+	for _, c := range cases {
+		names, more := testSearch(t, server, "alert", c.request)
+		assert.Equal(t, c.names, names, c.request)
+		assert.Equal(t, c.more, more, c.request)
+	}
+}
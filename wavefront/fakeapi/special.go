@@ -0,0 +1,355 @@
+package fakeapi
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// handleSpecial registers the endpoints besides the CRUD and search ones.
+func (s *Server) handleSpecial(mux *http.ServeMux) {
+	for _, name := range []string{alertKind, dashboardKind} {
+		k := s.collections[name].kind
+		mux.HandleFunc("PUT "+apiPrefix+k.path+"/acl/set", func(w http.ResponseWriter, r *http.Request) {
+			s.setACL(w, r, k)
+		})
+	}
+	mux.HandleFunc("POST "+apiPrefix+"alert/{id}/{action}", s.snoozeAlert)
+	mux.HandleFunc("POST "+apiPrefix+"dashboard/{id}/tag", s.setDashboardTags)
+	mux.HandleFunc("POST "+apiPrefix+"event/{id}/close", s.closeEvent)
+	mux.HandleFunc("POST "+apiPrefix+"usergroup/{id}/{action}", s.updateUserGroup)
+	mux.HandleFunc("POST "+apiPrefix+"role/{id}/{action}", s.updateRole)
+	mux.HandleFunc("POST "+apiPrefix+"apitoken/serviceaccount/{account}", s.createToken)
+	mux.HandleFunc("PUT "+apiPrefix+"apitoken/serviceaccount/{account}/{token}", s.updateToken)
+	mux.HandleFunc("DELETE "+apiPrefix+"apitoken/serviceaccount/{account}/{token}", s.deleteToken)
+	mux.HandleFunc("GET "+apiPrefix+"metricspolicy", func(w http.ResponseWriter, _ *http.Request) {
+		writeResponse(w, s.expandMetricsPolicy())
+	})
+	mux.HandleFunc("PUT "+apiPrefix+"metricspolicy", s.updateMetricsPolicy)
+	mux.HandleFunc("POST "+apiPrefix+"cloudintegration/awsExternalId", s.createExternalID)
+	mux.HandleFunc("GET "+apiPrefix+"cloudintegration/awsExternalId/{id}", s.getExternalID)
+	mux.HandleFunc("DELETE "+apiPrefix+"cloudintegration/awsExternalId/{id}", s.deleteExternalID)
+	mux.HandleFunc("POST "+apiPrefix+"search/{type}", s.search)
+}
+
+func (s *Server) setACL(w http.ResponseWriter, r *http.Request, k *kind) {
+	var acls []struct {
+		EntityID  string   `json:"entityId"`
+		ViewACL   []string `json:"viewAcl"`
+		ModifyACL []string `json:"modifyAcl"`
+	}
+	if !decodeBody(w, r, &acls) {
+		return
+	}
+	for _, acl := range acls {
+		e, ok := s.find(w, k, acl.EntityID)
+		if !ok {
+			return
+		}
+		e["acl"] = entity{
+			"entityId":  acl.EntityID,
+			"canView":   toInterfaces(acl.ViewACL),
+			"canModify": toInterfaces(acl.ModifyACL),
+		}
+	}
+	writeResponse(w, nil)
+}
+
+// snoozeAlert snoozes an alert for the seconds of the request, or
+// indefinitely, and unsnoozes it.
+func (s *Server) snoozeAlert(w http.ResponseWriter, r *http.Request) {
+	k := s.collections[alertKind].kind
+	e, ok := s.find(w, k, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	switch action := r.PathValue("action"); action {
+	case "snooze":
+		e["snoozed"] = int64(-1)
+		if seconds := r.URL.Query().Get("seconds"); seconds != "" {
+			n, err := strconv.ParseInt(seconds, 10, 64)
+			if err != nil || n <= 0 {
+				writeError(w, http.StatusBadRequest, "invalid seconds "+seconds)
+				return
+			}
+			e["snoozed"] = s.now() + n*1000
+		}
+	case "unsnooze":
+		delete(e, "snoozed")
+	default:
+		writeError(w, http.StatusNotFound, "unknown alert operation "+action)
+		return
+	}
+	s.writeEntity(w, k, e)
+}
+
+func (s *Server) setDashboardTags(w http.ResponseWriter, r *http.Request) {
+	e, ok := s.find(w, s.collections[dashboardKind].kind, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	var tags []string
+	if !decodeBody(w, r, &tags) {
+		return
+	}
+	e["tags"] = entity{"customerTags": toInterfaces(tags)}
+	writeResponse(w, nil)
+}
+
+func (s *Server) closeEvent(w http.ResponseWriter, r *http.Request) {
+	k := s.collections[eventKind].kind
+	e, ok := s.find(w, k, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	e["endTime"] = s.now()
+	e["runningState"] = "ENDED"
+	s.writeEntity(w, k, e)
+}
+
+// decodeIDs decodes a body holding a list of ids, checking that each is the
+// id of an entity found by find.
+func decodeIDs(w http.ResponseWriter, r *http.Request, find func(id string) bool) ([]string, bool) {
+	var ids []string
+	if !decodeBody(w, r, &ids) {
+		return nil, false
+	}
+	for _, id := range ids {
+		if !find(id) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s not found", id))
+			return nil, false
+		}
+	}
+	return ids, true
+}
+
+// updateUserGroup adds and removes the accounts and roles of a user group.
+func (s *Server) updateUserGroup(w http.ResponseWriter, r *http.Request) {
+	k := s.collections[userGroupKind].kind
+	group, ok := s.find(w, k, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	groupID := r.PathValue("id")
+	findAccount := func(id string) bool {
+		_, found := s.account(id)
+		return found
+	}
+	findRole := func(id string) bool {
+		_, found := s.collections[roleKind].get(id)
+		return found
+	}
+
+	switch action := r.PathValue("action"); action {
+	case "addUsers", "removeUsers":
+		ids, valid := decodeIDs(w, r, findAccount)
+		if !valid {
+			return
+		}
+		for _, id := range ids {
+			account, _ := s.account(id)
+			if action == "addUsers" {
+				account["userGroups"] = toInterfaces(addIDs(refs(account, "userGroups"), groupID))
+			} else {
+				account["userGroups"] = toInterfaces(removeIDs(refs(account, "userGroups"), groupID))
+			}
+		}
+	case "addRoles":
+		ids, valid := decodeIDs(w, r, findRole)
+		if !valid {
+			return
+		}
+		group["roles"] = toInterfaces(addIDs(refs(group, "roles"), ids...))
+	case "removeRoles":
+		ids, valid := decodeIDs(w, r, findRole)
+		if !valid {
+			return
+		}
+		group["roles"] = toInterfaces(removeIDs(refs(group, "roles"), ids...))
+	default:
+		writeError(w, http.StatusNotFound, "unknown user group operation "+action)
+		return
+	}
+	s.writeEntity(w, k, group)
+}
+
+// updateRole grants and revokes permissions of roles, and adds and removes
+// the accounts and groups a role is assigned to.
+func (s *Server) updateRole(w http.ResponseWriter, r *http.Request) {
+	k := s.collections[roleKind].kind
+	findRole := func(id string) bool {
+		_, found := s.collections[roleKind].get(id)
+		return found
+	}
+	if id := r.PathValue("id"); id == "grant" || id == "revoke" {
+		permission := r.PathValue("action")
+		ids, valid := decodeIDs(w, r, findRole)
+		if !valid {
+			return
+		}
+		roles := make([]interface{}, 0, len(ids))
+		for _, roleID := range ids {
+			role, _ := s.collections[roleKind].get(roleID)
+			permissions := referenceIDs(role["permissions"])
+			if id == "grant" {
+				permissions = addIDs(permissions, permission)
+			} else {
+				permissions = removeIDs(permissions, permission)
+			}
+			role["permissions"] = toInterfaces(permissions)
+			roles = append(roles, s.expandRole(role))
+		}
+		writeResponse(w, roles)
+		return
+	}
+
+	role, ok := s.find(w, k, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	roleID := r.PathValue("id")
+	action := r.PathValue("action")
+	if action != "addAssignees" && action != "removeAssignees" {
+		writeError(w, http.StatusNotFound, "unknown role operation "+action)
+		return
+	}
+	// Assignees are accounts or user groups.
+	assignee := func(id string) entity {
+		if e, found := s.account(id); found {
+			return e
+		}
+		e, _ := s.collections[userGroupKind].get(id)
+		return e
+	}
+	ids, valid := decodeIDs(w, r, func(id string) bool { return assignee(id) != nil })
+	if !valid {
+		return
+	}
+	for _, id := range ids {
+		e := assignee(id)
+		if action == "addAssignees" {
+			e["roles"] = toInterfaces(addIDs(refs(e, "roles"), roleID))
+		} else {
+			e["roles"] = toInterfaces(removeIDs(refs(e, "roles"), roleID))
+		}
+	}
+	s.writeEntity(w, k, role)
+}
+
+func (s *Server) serviceAccount(w http.ResponseWriter, r *http.Request) (entity, bool) {
+	return s.find(w, s.collections[serviceAccountKind].kind, r.PathValue("account"))
+}
+
+func tokens(account entity) []interface{} {
+	list, _ := account["tokens"].([]interface{})
+	return list
+}
+
+// createToken creates a token of a service account, responding with all of
+// its tokens.
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	account, ok := s.serviceAccount(w, r)
+	if !ok {
+		return
+	}
+	var options entity
+	if !decodeBody(w, r, &options) {
+		return
+	}
+	token := entity{
+		"tokenID":   s.newID(s.collections[serviceAccountKind].kind),
+		"tokenName": options["tokenName"],
+	}
+	account["tokens"] = append(tokens(account), token)
+	writeResponse(w, account["tokens"])
+}
+
+// token returns the token of the service account of the request, or writes
+// a 404.
+func (s *Server) token(w http.ResponseWriter, r *http.Request) (entity, int, bool) {
+	account, ok := s.serviceAccount(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+	for i, t := range tokens(account) {
+		if token := t.(entity); token["tokenID"] == r.PathValue("token") {
+			return account, i, true
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Sprintf("token %s not found", r.PathValue("token")))
+	return nil, 0, false
+}
+
+func (s *Server) updateToken(w http.ResponseWriter, r *http.Request) {
+	account, i, ok := s.token(w, r)
+	if !ok {
+		return
+	}
+	var options entity
+	if !decodeBody(w, r, &options) {
+		return
+	}
+	token := tokens(account)[i].(entity)
+	token["tokenName"] = options["tokenName"]
+	writeResponse(w, token)
+}
+
+func (s *Server) deleteToken(w http.ResponseWriter, r *http.Request) {
+	account, i, ok := s.token(w, r)
+	if !ok {
+		return
+	}
+	list := tokens(account)
+	account["tokens"] = append(list[:i:i], list[i+1:]...)
+	writeResponse(w, nil)
+}
+
+// updateMetricsPolicy replaces the rules of the metrics policy.
+func (s *Server) updateMetricsPolicy(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		PolicyRules []entity `json:"policyRules"`
+	}
+	if !decodeBody(w, r, &request) {
+		return
+	}
+	rules := make([]interface{}, 0, len(request.PolicyRules))
+	for _, rule := range request.PolicyRules {
+		for _, key := range []string{"accounts", "userGroups", "roles"} {
+			rule[key] = toInterfaces(refs(rule, key))
+		}
+		rules = append(rules, rule)
+	}
+	s.metricsPolicy["policyRules"] = rules
+	s.metricsPolicy["updaterId"] = Updater
+	s.metricsPolicy["updatedEpochMillis"] = s.now()
+	writeResponse(w, s.expandMetricsPolicy())
+}
+
+func (s *Server) createExternalID(w http.ResponseWriter, _ *http.Request) {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+	s.externalIDs[id] = true
+	writeResponse(w, id)
+}
+
+func (s *Server) getExternalID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !s.externalIDs[id] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("external id %s not found", id))
+		return
+	}
+	writeResponse(w, id)
+}
+
+func (s *Server) deleteExternalID(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if !s.externalIDs[id] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("external id %s not found", id))
+		return
+	}
+	delete(s.externalIDs, id)
+	writeResponse(w, id)
+}
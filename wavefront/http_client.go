@@ -0,0 +1,58 @@
+package wavefront
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"unsafe"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+)
+
+// The Wavefront client doesn't let callers provide the *http.Client it sends
+// requests with, nor a context for them. Its httpClient field is therefore
+// read and replaced through reflection. TestHTTPClientField guards against
+// the field going away when the Wavefront client is upgraded.
+const httpClientFieldName = "httpClient"
+
+func httpClientField(c *wavefront.Client) reflect.Value {
+	field := reflect.ValueOf(c).Elem().FieldByName(httpClientFieldName)
+	return reflect.NewAt(field.Type(), unsafe.Pointer(field.UnsafeAddr())).Elem()
+}
+
+// getHTTPClient returns the *http.Client that c sends requests with.
+func getHTTPClient(c *wavefront.Client) *http.Client {
+	return httpClientField(c).Interface().(*http.Client)
+}
+
+// setHTTPClient makes c send requests with httpClient.
+func setHTTPClient(c *wavefront.Client, httpClient *http.Client) {
+	httpClientField(c).Set(reflect.ValueOf(httpClient))
+}
+
+// contextTransport binds every request it sends to ctx so that requests are
+// cancelled when ctx is, e.g. when Terraform is interrupted or an operation
+// times out.
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx))
+}
+
+// clientWithContext returns a copy of c whose requests are bound to ctx.
+func clientWithContext(ctx context.Context, c *wavefront.Client) *wavefront.Client {
+	base := getHTTPClient(c)
+	transport := base.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	httpClient := *base
+	httpClient.Transport = &contextTransport{ctx: ctx, base: transport}
+
+	clientCopy := *c
+	setHTTPClient(&clientCopy, &httpClient)
+	return &clientCopy
+}
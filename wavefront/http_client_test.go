@@ -0,0 +1,59 @@
+package wavefront
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClientField(t *testing.T) {
+	client, err := wavefront.NewClient(&wavefront.Config{Address: "wavefront.example.com", Token: "secret"})
+	require.NoError(t, err)
+
+	httpClient := getHTTPClient(client)
+	require.NotNil(t, httpClient)
+
+	replacement := &http.Client{}
+	setHTTPClient(client, replacement)
+	assert.Same(t, replacement, getHTTPClient(client))
+}
+
+func TestClientWithContext(t *testing.T) {
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-released:
+		}
+	}))
+	defer server.Close()
+	defer close(released)
+
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	original := getHTTPClient(client)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	bound := clientWithContext(ctx, client)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := bound.Alerts().Find(nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled with its context")
+	}
+	assert.Same(t, original, getHTTPClient(client), "the original client must not be modified")
+}
@@ -0,0 +1,32 @@
+package wavefront
+
+import (
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAlertJSON_Basic(t *testing.T) {
+	resourceName := "wavefront_alert_json.test_alert_json"
+	var record wavefront.Alert
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertJSONBasic("Terraform Test Alert JSON Import", 80),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontAlertJSONExists(resourceName, &record),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
@@ -37,6 +37,12 @@ func TestAccCloudIntegration_CloudWatch(t *testing.T) {
 	testCloudIntegrationCommon(t, resourceName, record, testAccCheckWavefrontCloudIntegrationCloudWatchBasic())
 }
 
+func TestAccCloudIntegration_Dynatrace(t *testing.T) {
+	var record wavefront.CloudIntegration
+	resourceName := "wavefront_cloud_integration_dynatrace.dynatrace"
+	testCloudIntegrationCommon(t, resourceName, record, testAccCheckWavefrontCloudIntegrationDynatraceBasic())
+}
+
 func TestAccCloudIntegration_Ec2(t *testing.T) {
 	var record wavefront.CloudIntegration
 	resourceName := "wavefront_cloud_integration_ec2.ec2"
@@ -61,6 +67,18 @@ func TestAccCloudIntegration_NewRelic(t *testing.T) {
 	testCloudIntegrationCommon(t, resourceName, record, testAccCheckWavefrontCloudIntegrationNewRelicBasic())
 }
 
+func TestAccCloudIntegration_Snowflake(t *testing.T) {
+	var record wavefront.CloudIntegration
+	resourceName := "wavefront_cloud_integration_snowflake.snowflake"
+	testCloudIntegrationCommon(t, resourceName, record, testAccCheckWavefrontCloudIntegrationSnowflakeBasic())
+}
+
+func TestAccCloudIntegration_Vrops(t *testing.T) {
+	var record wavefront.CloudIntegration
+	resourceName := "wavefront_cloud_integration_vrops.vrops"
+	testCloudIntegrationCommon(t, resourceName, record, testAccCheckWavefrontCloudIntegrationVropsBasic())
+}
+
 func testCloudIntegrationCommon(t *testing.T, resourceName string, record wavefront.CloudIntegration, config string) {
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -78,6 +96,7 @@ func testCloudIntegrationCommon(t *testing.T, resourceName string, record wavefr
 				ImportState:  true,
 				ImportStateVerifyIgnore: []string{
 					"force_save", "encrypted_password", "client_secret", "json_key", "api_key", "password",
+					"api_token", "private_key", "api_request.0.password",
 				},
 				ImportStateVerify: true,
 			},
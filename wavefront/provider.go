@@ -1,14 +1,25 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type wavefrontClient struct {
 	client wavefront.Client
+	// defaultTags are the tags added to every alert, derived metric, event
+	// and dashboard.
+	defaultTags []string
+}
+
+// withContext returns the Wavefront client with its requests bound to ctx.
+func (c *wavefrontClient) withContext(ctx context.Context) *wavefront.Client {
+	return clientWithContext(ctx, &c.client)
 }
 
 func Provider() *schema.Provider {
@@ -16,21 +27,120 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"address": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_ADDRESS", ""),
 			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_PROFILE", ""),
+			},
+			"credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_CREDENTIALS_FILE", ""),
+			},
 			"token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_TOKEN", ""),
 			},
+			"csp_api_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_CSP_API_TOKEN", ""),
+			},
+			"csp_app_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_CSP_APP_ID", ""),
+			},
+			"csp_app_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_CSP_APP_SECRET", ""),
+			},
+			"csp_org_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("WAVEFRONT_CSP_ORG_ID", ""),
+			},
+			"csp_base_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("WAVEFRONT_CSP_BASE_URL", defaultCSPBaseURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+			},
 			"http_proxy": {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+			},
+			"client_cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				RequiredWith: []string{"client_cert"},
+			},
+			"insecure_skip_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultMaxRetries,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"min_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultMinBackoff,
+				ValidateFunc: validateDuration,
+			},
+			"max_backoff": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultMaxBackoff,
+				ValidateFunc: validateDuration,
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			defaultTagsKey: defaultTagsSchema(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"wavefront_alert":                                resourceAlert(),
+			"wavefront_alert_acl":                            resourceAlertACL(),
+			"wavefront_alert_json":                           resourceAlertJSON(),
+			"wavefront_alert_snooze":                         resourceAlertSnooze(),
 			"wavefront_alert_target":                         resourceTarget(),
 			"wavefront_cloud_integration_app_dynamics":       resourceCloudIntegrationAppDynamics(),
 			"wavefront_cloud_integration_aws_external_id":    resourceCloudIntegrationAwsExternalID(),
@@ -38,21 +148,30 @@ func Provider() *schema.Provider {
 			"wavefront_cloud_integration_azure_activity_log": resourceCloudIntegrationAzureActivityLog(),
 			"wavefront_cloud_integration_cloudwatch":         resourceCloudIntegrationCloudWatch(),
 			"wavefront_cloud_integration_cloudtrail":         resourceCloudIntegrationCloudTrail(),
+			"wavefront_cloud_integration_dynatrace":          resourceCloudIntegrationDynatrace(),
 			"wavefront_cloud_integration_ec2":                resourceCloudIntegrationEc2(),
 			"wavefront_cloud_integration_gcp":                resourceCloudIntegrationGcp(),
 			"wavefront_cloud_integration_gcp_billing":        resourceCloudIntegrationGcpBilling(),
 			"wavefront_cloud_integration_newrelic":           resourceCloudIntegrationNewRelic(),
+			"wavefront_cloud_integration_snowflake":          resourceCloudIntegrationSnowflake(),
+			"wavefront_cloud_integration_vrops":              resourceCloudIntegrationVrops(),
 			"wavefront_dashboard":                            resourceDashboard(),
+			"wavefront_dashboard_acl":                        resourceDashboardACL(),
 			"wavefront_dashboard_json":                       resourceDashboardJSON(),
 			"wavefront_derived_metric":                       resourceDerivedMetric(),
 			"wavefront_external_link":                        resourceExternalLink(),
 			"wavefront_ingestion_policy":                     resourceIngestionPolicy(),
 			"wavefront_maintenance_window":                   resourceMaintenanceWindow(),
 			"wavefront_metrics_policy":                       resourceMetricsPolicy(),
+			"wavefront_metrics_policy_rule":                  resourceMetricsPolicyRule(),
+			"wavefront_recurring_maintenance_window":         resourceRecurringMaintenanceWindow(),
 			"wavefront_service_account":                      resourceServiceAccount(),
+			"wavefront_service_account_token":                resourceServiceAccountToken(),
 			"wavefront_role":                                 resourceRole(),
+			"wavefront_role_assignment":                      resourceRoleAssignment(),
 			"wavefront_user":                                 resourceUser(),
 			"wavefront_user_group":                           resourceUserGroup(),
+			"wavefront_user_group_membership":                resourceUserGroupMembership(),
 			"wavefront_event":                                resourceEvent(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
@@ -70,6 +189,15 @@ func Provider() *schema.Provider {
 			"wavefront_maintenance_window":     dataSourceMaintenanceWindow(),
 			"wavefront_alerts":                 dataSourceAlerts(),
 			"wavefront_alert":                  dataSourceAlert(),
+			"wavefront_alert_snooze":           dataSourceAlertSnooze(),
+			"wavefront_alert_target":           dataSourceAlertTarget(),
+			"wavefront_alert_targets":          dataSourceAlertTargets(),
+			"wavefront_cloud_integration":      dataSourceCloudIntegration(),
+			"wavefront_cloud_integrations":     dataSourceCloudIntegrations(),
+			"wavefront_ingestion_policy":       dataSourceIngestionPolicy(),
+			"wavefront_ingestion_policies":     dataSourceIngestionPolicies(),
+			"wavefront_service_account":        dataSourceServiceAccount(),
+			"wavefront_service_accounts":       dataSourceServiceAccounts(),
 			"wavefront_derived_metrics":        dataSourceDerivedMetrics(),
 			"wavefront_derived_metric":         dataSourceDerivedMetric(),
 			"wavefront_event":                  dataSourceEvent(),
@@ -81,21 +209,114 @@ func Provider() *schema.Provider {
 	}
 }
 
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = "1s"
+	defaultMaxBackoff = "30s"
+)
+
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	credentials, err := resolveProviderCredentials(d)
+	if err != nil {
+		return nil, err
+	}
 	config := &wavefront.Config{
-		Address:   d.Get("address").(string),
-		Token:     d.Get("token").(string),
+		Address:   credentials.Address,
+		Token:     credentials.Token,
 		HttpProxy: d.Get("http_proxy").(string),
 	}
 	wFClient, err := wavefront.NewClient(config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to configure Wavefront Client %s", err)
 	}
+
+	// Durations were validated by validateDuration.
+	minBackoff, _ := time.ParseDuration(d.Get("min_backoff").(string))
+	maxBackoff, _ := time.ParseDuration(d.Get("max_backoff").(string))
+	if minBackoff > maxBackoff {
+		return nil, fmt.Errorf("min_backoff (%s) must not be greater than max_backoff (%s)", minBackoff, maxBackoff)
+	}
+	tlsConfig, err := tlsOptions{
+		CACertFile:         d.Get("ca_cert_file").(string),
+		CACertPEM:          d.Get("ca_cert_pem").(string),
+		ClientCert:         d.Get("client_cert").(string),
+		ClientKey:          d.Get("client_key").(string),
+		InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+	}.config()
+	if err != nil {
+		return nil, err
+	}
+	// The transport is shared by every copy of the client made by withContext,
+	// so the limits apply to all resources together.
+	httpClient := getHTTPClient(wFClient)
+	if err = applyTLSConfig(httpClient, tlsConfig); err != nil {
+		return nil, err
+	}
+	if credentials.Token == "" {
+		source := newCSPTokenSource(credentials.CSP, httpClient.Transport)
+		httpClient.Transport = newCSPAuthTransport(httpClient.Transport, source)
+	}
+	limited := newRateLimitTransport(httpClient.Transport,
+		d.Get("requests_per_second").(float64), d.Get("max_concurrent_requests").(int))
+	httpClient.Transport = newRetryTransport(limited, d.Get("max_retries").(int), minBackoff, maxBackoff)
+
 	return &wavefrontClient{
-		client: *wFClient,
+		client:      *wFClient,
+		defaultTags: parseDefaultTags(d),
 	}, nil
 }
 
+// resolveProviderCredentials returns the address and credentials set in the
+// provider block or the environment, completed by the ones of the profile.
+func resolveProviderCredentials(d *schema.ResourceData) (*providerCredentials, error) {
+	credentials := &providerCredentials{
+		Address: d.Get("address").(string),
+		Token:   d.Get("token").(string),
+		CSP: cspCredentials{
+			BaseURL:   d.Get("csp_base_url").(string),
+			APIToken:  d.Get("csp_api_token").(string),
+			AppID:     d.Get("csp_app_id").(string),
+			AppSecret: d.Get("csp_app_secret").(string),
+			OrgID:     d.Get("csp_org_id").(string),
+		},
+	}
+	profile, err := loadCredentialsProfile(d.Get("credentials_file").(string), d.Get("profile").(string))
+	if err != nil {
+		return nil, err
+	}
+	if profile != nil {
+		credentials.mergeProfile(profile)
+	}
+
+	if credentials.Address == "" {
+		return nil, fmt.Errorf("address must be set in the provider block, WAVEFRONT_ADDRESS or a profile")
+	}
+	csp := credentials.CSP
+	if (csp.AppID == "") != (csp.AppSecret == "") {
+		return nil, fmt.Errorf("csp_app_id and csp_app_secret must be set together")
+	}
+	modes := 0
+	for _, set := range []bool{credentials.Token != "", csp.APIToken != "", csp.AppID != ""} {
+		if set {
+			modes++
+		}
+	}
+	switch {
+	case modes == 0:
+		return nil, fmt.Errorf("one of token, csp_api_token or csp_app_id and csp_app_secret must be set")
+	case modes > 1:
+		return nil, fmt.Errorf("only one of token, csp_api_token or csp_app_id and csp_app_secret can be set")
+	}
+	return credentials, nil
+}
+
+func validateDuration(val interface{}, key string) (warnings []string, errors []error) {
+	if _, err := time.ParseDuration(val.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s must be a duration such as 500ms or 1m, got %q", key, val))
+	}
+	return warnings, errors
+}
+
 var wfMutexKV = NewMutexKV()
 
 const (
@@ -104,8 +325,11 @@ const (
 	wfAzureActivityLog string = "AZUREACTIVITYLOG"
 	wfCloudTrail       string = "CLOUDTRAIL"
 	wfCloudWatch       string = "CLOUDWATCH"
+	wfDynatrace        string = "DYNATRACE"
 	wfEc2              string = "EC2"
 	wfGcp              string = "GCP"
 	wfGcpBilling       string = "GCPBILLING"
 	wfNewRelic         string = "NEWRELIC"
+	wfSnowflake        string = "SNOWFLAKE"
+	wfVrops            string = "VROPS"
 )
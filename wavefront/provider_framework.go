@@ -0,0 +1,141 @@
+package wavefront
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// frameworkProvider is the terraform-plugin-framework half of the provider.
+// It is served next to the SDKv2 provider by ProtoV6ProviderServerFactory so
+// that new resources can be written on the framework and existing ones can be
+// moved over one by one.
+type frameworkProvider struct {
+	// primary is the SDKv2 provider. The mux server configures it before this
+	// provider, and its meta is shared so both halves use the same client.
+	primary *schema.Provider
+}
+
+var _ provider.Provider = (*frameworkProvider)(nil)
+
+// NewFrameworkProvider returns the plugin framework provider that shares its
+// configuration with primary.
+func NewFrameworkProvider(primary *schema.Provider) provider.Provider {
+	return &frameworkProvider{primary: primary}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "wavefront"
+}
+
+// Schema must match the schema of the SDKv2 provider exactly, otherwise the
+// mux server refuses to start.
+func (p *frameworkProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"address": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"profile": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"credentials_file": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"token": fwschema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"csp_api_token": fwschema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"csp_app_id": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"csp_app_secret": fwschema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"csp_org_id": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"csp_base_url": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"http_proxy": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"ca_cert_file": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"ca_cert_pem": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"client_cert": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"client_key": fwschema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"insecure_skip_verify": fwschema.BoolAttribute{
+				Optional: true,
+			},
+			"max_retries": fwschema.Int64Attribute{
+				Optional: true,
+			},
+			"min_backoff": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"max_backoff": fwschema.StringAttribute{
+				Optional: true,
+			},
+			"requests_per_second": fwschema.Float64Attribute{
+				Optional: true,
+			},
+			"max_concurrent_requests": fwschema.Int64Attribute{
+				Optional: true,
+			},
+		},
+		Blocks: map[string]fwschema.Block{
+			defaultTagsKey: fwschema.ListNestedBlock{
+				NestedObject: fwschema.NestedBlockObject{
+					Attributes: map[string]fwschema.Attribute{
+						tagsKey: fwschema.SetAttribute{
+							ElementType: types.StringType,
+							Required:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(_ context.Context, _ provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	meta := p.primary.Meta()
+	if meta == nil {
+		resp.Diagnostics.AddError(
+			"Wavefront Provider Not Configured",
+			"The plugin framework provider was configured before the SDKv2 provider. "+
+				"This is a bug in the provider, please report it.",
+		)
+		return
+	}
+	resp.DataSourceData = meta
+	resp.ResourceData = meta
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{}
+}
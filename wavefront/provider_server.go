@@ -0,0 +1,120 @@
+package wavefront
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	ctymsgpack "github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProtoV6ProviderServerFactory returns a factory for the protocol version 6
+// server of the provider. The server muxes primary, upgraded from protocol
+// version 5, with the plugin framework provider. primary is listed first so
+// that it's configured before the framework provider, which reuses its meta.
+func ProtoV6ProviderServerFactory(ctx context.Context, primary *schema.Provider) (func() tfprotov6.ProviderServer, error) {
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return schema.NewGRPCProviderServer(primary)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	servers := []func() tfprotov6.ProviderServer{
+		func() tfprotov6.ProviderServer {
+			return planWarningServer{ProviderServer: upgradedSdkServer, primary: primary}
+		},
+		providerserver.NewProtocol6(NewFrameworkProvider(primary)),
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx, servers...)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// planWarningFunc returns the warnings to add to the plan of a resource given
+// its prior and planned state, which are null when it's created or destroyed.
+type planWarningFunc func(prior, planned cty.Value) diag.Diagnostics
+
+// planWarningFuncs are the plan warnings of SDKv2 resources, keyed by their
+// type. CustomizeDiff functions can only fail a plan, so planWarningServer
+// adds these warnings to the plans of the SDKv2 provider instead.
+var planWarningFuncs = map[string]planWarningFunc{
+	"wavefront_dashboard_json": resourceDashboardJSONPlanWarnings,
+}
+
+// planWarningServer adds the warnings of planWarningFuncs to the plans of the
+// resources of the SDKv2 provider primary served by ProviderServer.
+type planWarningServer struct {
+	tfprotov6.ProviderServer
+	primary *schema.Provider
+}
+
+func (s planWarningServer) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanResourceChangeRequest) (*tfprotov6.PlanResourceChangeResponse, error) {
+	resp, err := s.ProviderServer.PlanResourceChange(ctx, req)
+	warnings, ok := planWarningFuncs[req.TypeName]
+	resource, found := s.primary.ResourcesMap[req.TypeName]
+	if err != nil || resp == nil || !ok || !found {
+		return resp, err
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			return resp, nil
+		}
+	}
+
+	ty := resource.CoreConfigSchema().ImpliedType()
+	prior, priorErr := decodeDynamicValue(req.PriorState, ty)
+	planned, plannedErr := decodeDynamicValue(resp.PlannedState, ty)
+	if priorErr != nil || plannedErr != nil {
+		log.Printf("[WARN] failed to decode the plan of %s, %v %v", req.TypeName, priorErr, plannedErr)
+		return resp, nil
+	}
+	for _, d := range warnings(prior, planned) {
+		resp.Diagnostics = append(resp.Diagnostics, &tfprotov6.Diagnostic{
+			Severity:  tfprotov6.DiagnosticSeverityWarning,
+			Summary:   d.Summary,
+			Detail:    d.Detail,
+			Attribute: attributePath(d.AttributePath),
+		})
+	}
+	return resp, nil
+}
+
+// decodeDynamicValue decodes the state value of type ty, which is null when
+// value is.
+func decodeDynamicValue(value *tfprotov6.DynamicValue, ty cty.Type) (cty.Value, error) {
+	switch {
+	case value == nil:
+		return cty.NullVal(ty), nil
+	case len(value.MsgPack) > 0:
+		return ctymsgpack.Unmarshal(value.MsgPack, ty)
+	case len(value.JSON) > 0:
+		return ctyjson.Unmarshal(value.JSON, ty)
+	}
+	return cty.NullVal(ty), nil
+}
+
+// attributePath converts the path of an attribute of a diagnostic. Only the
+// top level attributes of resources are converted, other paths are dropped.
+func attributePath(path cty.Path) *tftypes.AttributePath {
+	if len(path) != 1 {
+		return nil
+	}
+	step, ok := path[0].(cty.GetAttrStep)
+	if !ok {
+		return nil
+	}
+	return tftypes.NewAttributePath().WithAttributeName(step.Name)
+}
@@ -0,0 +1,151 @@
+package wavefront
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctymsgpack "github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testProviderConfigValue returns a provider configuration with the given
+// address and token, leaving every other attribute of the provider null and
+// every block empty.
+func testProviderConfigValue(t *testing.T, address, token string) tfprotov6.DynamicValue {
+	attributeTypes := map[string]tftypes.Type{}
+	attributeValues := map[string]tftypes.Value{}
+	for name, attribute := range Provider().Schema {
+		switch attribute.Type {
+		case schema.TypeString:
+			attributeTypes[name] = tftypes.String
+		case schema.TypeInt, schema.TypeFloat:
+			attributeTypes[name] = tftypes.Number
+		case schema.TypeBool:
+			attributeTypes[name] = tftypes.Bool
+		case schema.TypeList:
+			if name != defaultTagsKey {
+				t.Fatalf("unsupported provider block %s", name)
+			}
+			attributeTypes[name] = tftypes.List{ElementType: tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{tagsKey: tftypes.Set{ElementType: tftypes.String}},
+			}}
+			attributeValues[name] = tftypes.NewValue(attributeTypes[name], []tftypes.Value{})
+			continue
+		default:
+			t.Fatalf("unsupported type %s of provider attribute %s", attribute.Type, name)
+		}
+		attributeValues[name] = tftypes.NewValue(attributeTypes[name], nil)
+	}
+	attributeValues["address"] = tftypes.NewValue(tftypes.String, address)
+	attributeValues["token"] = tftypes.NewValue(tftypes.String, token)
+
+	configType := tftypes.Object{AttributeTypes: attributeTypes}
+	config, err := tfprotov6.NewDynamicValue(configType, tftypes.NewValue(configType, attributeValues))
+	require.NoError(t, err)
+	return config
+}
+
+func TestProtoV6ProviderServer_GetProviderSchema(t *testing.T) {
+	server, err := testAccProtoV6ProviderFactories["wavefront"]()
+	require.NoError(t, err)
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+
+	for name := range Provider().ResourcesMap {
+		assert.Contains(t, resp.ResourceSchemas, name)
+	}
+	for name := range Provider().DataSourcesMap {
+		assert.Contains(t, resp.DataSourceSchemas, name)
+	}
+}
+
+func TestProtoV6ProviderServer_ConfigureProvider(t *testing.T) {
+	primary := Provider()
+	factory, err := ProtoV6ProviderServerFactory(context.Background(), primary)
+	require.NoError(t, err)
+	server := factory()
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.5.0",
+		Config:           pointerTo(testProviderConfigValue(t, "wavefront.example.com", "secret")),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics)
+
+	meta, ok := primary.Meta().(*wavefrontClient)
+	require.True(t, ok)
+	assert.Equal(t, "https://wavefront.example.com/api/v2/", meta.client.BaseURL.String())
+}
+
+func TestFrameworkProvider_ConfigureWithoutPrimary(t *testing.T) {
+	server := providerserver.NewProtocol6(NewFrameworkProvider(Provider()))()
+
+	resp, err := server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.5.0",
+		Config:           pointerTo(testProviderConfigValue(t, "wavefront.example.com", "secret")),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityError, resp.Diagnostics[0].Severity)
+	assert.Equal(t, "Wavefront Provider Not Configured", resp.Diagnostics[0].Summary)
+}
+
+func TestProtoV6ProviderServer_PlanWarnings(t *testing.T) {
+	primary := Provider()
+	factory, err := ProtoV6ProviderServerFactory(context.Background(), primary)
+	require.NoError(t, err)
+	server := factory()
+
+	ty := primary.ResourcesMap["wavefront_dashboard_json"].CoreConfigSchema().ImpliedType()
+	dashboardValue := func(id, dashboardJSON string) *tfprotov6.DynamicValue {
+		attributes := map[string]cty.Value{}
+		for name, attributeType := range ty.AttributeTypes() {
+			attributes[name] = cty.NullVal(attributeType)
+		}
+		if id != "" {
+			attributes["id"] = cty.StringVal(id)
+		}
+		attributes["dashboard_json"] = cty.StringVal(dashboardJSON)
+		value, marshalErr := ctymsgpack.Marshal(cty.ObjectVal(attributes), ty)
+		require.NoError(t, marshalErr)
+		return &tfprotov6.DynamicValue{MsgPack: value}
+	}
+	prior := NormalizeDashboardJSON(testDashboardJSON)
+	changed := strings.Replace(testDashboardJSON, "> 90", "> 95", 1)
+
+	resp, err := server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "wavefront_dashboard_json",
+		PriorState:       dashboardValue("tftestimport", prior),
+		ProposedNewState: dashboardValue("tftestimport", changed),
+		Config:           dashboardValue("", changed),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Diagnostics, 1)
+	assert.Equal(t, tfprotov6.DiagnosticSeverityWarning, resp.Diagnostics[0].Severity)
+	assert.Equal(t, "Wavefront Dashboard tftestimport changes", resp.Diagnostics[0].Summary)
+	assert.Equal(t, "dashboard_json changes at:\n  sections[0].rows[0].charts[0].sources[0].query",
+		resp.Diagnostics[0].Detail)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("dashboard_json"), resp.Diagnostics[0].Attribute)
+
+	resp, err = server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "wavefront_dashboard_json",
+		PriorState:       dashboardValue("tftestimport", prior),
+		ProposedNewState: dashboardValue("tftestimport", testDashboardJSON),
+		Config:           dashboardValue("", testDashboardJSON),
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Diagnostics, "unchanged dashboards must not be warned about")
+}
+
+func pointerTo[T any](v T) *T {
+	return &v
+}
@@ -1,21 +1,43 @@
 package wavefront
 
 import (
+	"context"
+	"sync"
 	"testing"
 
 	"os"
 
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/fakeapi"
 )
 
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
+// testAccProtoV6ProviderFactories serve testAccProvider muxed with the plugin
+// framework provider, as the provider binary does. Tests of resources written
+// on the plugin framework must use them instead of testAccProviders.
+var testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
+
 func init() {
 	testAccProvider = Provider()
 	testAccProviders = map[string]*schema.Provider{
 		"wavefront": testAccProvider,
 	}
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"wavefront": func() (tfprotov6.ProviderServer, error) {
+			factory, err := ProtoV6ProviderServerFactory(context.Background(), testAccProvider)
+			if err != nil {
+				return nil, err
+			}
+			return factory(), nil
+		},
+	}
 }
 
 func TestProvider(t *testing.T) {
@@ -28,11 +50,72 @@ func TestProvider_impl(_ *testing.T) {
 	var _ *schema.Provider = Provider()
 }
 
+// testAccFakeAPI is the fake Wavefront API the acceptance tests run against
+// when WAVEFRONT_FAKEAPI is set. Like a tenant, it's shared by all the tests.
+var (
+	testAccFakeAPI     *fakeapi.Server
+	testAccFakeAPIOnce sync.Once
+)
+
 func testAccPreCheck(t *testing.T) {
-	if v := os.Getenv("WAVEFRONT_TOKEN"); v == "" {
-		t.Fatal("WAVEFRONT_TOKEN must be set for acceptance tests")
+	if os.Getenv("WAVEFRONT_FAKEAPI") != "" {
+		testAccFakeAPIOnce.Do(func() {
+			testAccFakeAPI = fakeapi.NewServer()
+		})
+		for _, name := range testProviderEnv {
+			t.Setenv(name, "")
+		}
+		t.Setenv("WAVEFRONT_ADDRESS", testAccFakeAPI.Address())
+		t.Setenv("WAVEFRONT_TOKEN", fakeapi.Token)
+		return
+	}
+	if os.Getenv("WAVEFRONT_PROFILE") != "" {
+		return
+	}
+	if os.Getenv("WAVEFRONT_TOKEN") == "" && os.Getenv("WAVEFRONT_CSP_API_TOKEN") == "" &&
+		os.Getenv("WAVEFRONT_CSP_APP_ID") == "" {
+		t.Fatal("WAVEFRONT_TOKEN, WAVEFRONT_CSP_API_TOKEN or WAVEFRONT_CSP_APP_ID must be set for acceptance tests")
 	}
 	if v := os.Getenv("WAVEFRONT_ADDRESS"); v == "" {
 		t.Fatal("WAVEFRONT_ADDRESS must be set for acceptance tests")
 	}
 }
+
+// fakeAPIProvider returns a provider configured against the fake Wavefront
+// API, for the tests driving resources and data sources directly.
+func fakeAPIProvider(t *testing.T) *schema.Provider {
+	t.Helper()
+	return fakeAPIProviderWithConfig(t, nil)
+}
+
+// fakeAPIProviderWithConfig returns a provider configured with raw against
+// the fake Wavefront API.
+func fakeAPIProviderWithConfig(t *testing.T, raw map[string]interface{}) *schema.Provider {
+	t.Helper()
+	t.Setenv("WAVEFRONT_FAKEAPI", "1")
+	testAccPreCheck(t)
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	require.False(t, diags.HasError(), "%v", diags)
+	return provider
+}
+
+func TestPreCheck_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+
+	resource := resourceUserGroup()
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":        "Offline",
+		"description": "Created against the fake API",
+	})
+	diags := resource.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Equal(t, "Offline", d.Get("name"))
+
+	id := d.Id()
+	diags = resource.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	err := provider.Meta().(*wavefrontClient).client.UserGroups().Get(&wavefront.UserGroup{ID: &id})
+	assert.True(t, wavefront.NotFound(err), "the user group must be deleted, got %v", err)
+}
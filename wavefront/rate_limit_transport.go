@@ -0,0 +1,59 @@
+package wavefront
+
+import (
+	"math"
+	"net/http"
+
+	"golang.org/x/time/rate"
+)
+
+// rateLimitTransport throttles the requests sent through it: each request
+// takes a token from a bucket refilled at requestsPerSecond, and at most
+// maxConcurrent requests are in flight at once. A request stops counting
+// against maxConcurrent once its response headers are received, as the client
+// library doesn't close every response body. It sits below retryTransport so
+// that every attempt is throttled, not just the first one.
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	limiter *rate.Limiter
+	slots   chan struct{}
+}
+
+// newRateLimitTransport returns a transport limiting requests to base. Zero
+// requestsPerSecond or maxConcurrent disables the respective limit.
+func newRateLimitTransport(base http.RoundTripper, requestsPerSecond float64, maxConcurrent int) *rateLimitTransport {
+	t := &rateLimitTransport{base: base}
+	if requestsPerSecond > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), int(math.Ceil(requestsPerSecond)))
+	}
+	if maxConcurrent > 0 {
+		t.slots = make(chan struct{}, maxConcurrent)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if t.limiter != nil {
+		if err := t.limiter.Wait(ctx); err != nil {
+			t.release()
+			return nil, err
+		}
+	}
+
+	defer t.release()
+	return t.base.RoundTrip(req)
+}
+
+func (t *rateLimitTransport) release() {
+	if t.slots != nil {
+		<-t.slots
+	}
+}
@@ -0,0 +1,100 @@
+package wavefront
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRateLimitedClient returns a Wavefront client for server that is limited
+// like the provider's.
+func testRateLimitedClient(t *testing.T, server *httptest.Server, requestsPerSecond float64, maxConcurrent int) *wavefront.Client {
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	httpClient := getHTTPClient(client)
+	httpClient.Transport = newRateLimitTransport(httpClient.Transport, requestsPerSecond, maxConcurrent)
+	return client
+}
+
+func TestRateLimitTransport_LimitsRequestsPerSecond(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(alertHandler))
+	defer server.Close()
+
+	client := testRateLimitedClient(t, server, 10, 0)
+	start := time.Now()
+	// The bucket starts with 10 tokens, the other 5 requests wait for refills.
+	for i := 0; i < 15; i++ {
+		id := "1234"
+		require.NoError(t, client.Alerts().Get(&wavefront.Alert{ID: &id}))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+func TestRateLimitTransport_LimitsConcurrentRequests(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		alertHandler(w, r)
+	}))
+	defer server.Close()
+
+	client := testRateLimitedClient(t, server, 0, 2)
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id := "1234"
+			// Copies share the transport, like the ones made by withContext.
+			assert.NoError(t, clientWithContext(context.Background(), client).Alerts().Get(&wavefront.Alert{ID: &id}))
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 2, atomic.LoadInt32(&maxInFlight))
+}
+
+func TestRateLimitTransport_StopsWhenCancelled(t *testing.T) {
+	released := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-released
+		alertHandler(w, r)
+	}))
+	defer server.Close()
+	defer close(released)
+
+	client := testRateLimitedClient(t, server, 0, 1)
+	go func() {
+		id := "1234"
+		_ = client.Alerts().Get(&wavefront.Alert{ID: &id})
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	// Wait for the first request to take the only slot.
+	time.Sleep(10 * time.Millisecond)
+	id := "1234"
+	err := clientWithContext(ctx, client).Alerts().Get(&wavefront.Alert{ID: &id})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRateLimitTransport_Unlimited(t *testing.T) {
+	transport := newRateLimitTransport(http.DefaultTransport, 0, 0)
+	assert.Nil(t, transport.limiter)
+	assert.Nil(t, transport.slots)
+}
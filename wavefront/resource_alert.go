@@ -1,22 +1,27 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceAlert() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAlertCreate,
-		Read:   resourceAlertRead,
-		Update: resourceAlertUpdate,
-		Delete: resourceAlertDelete,
+		CreateContext: resourceAlertCreate,
+		ReadContext:   resourceAlertRead,
+		UpdateContext: resourceAlertUpdate,
+		DeleteContext: resourceAlertDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffTagsAll,
+		Timeouts:      resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			nameKey: {
 				Type:     schema.TypeString,
@@ -40,11 +45,13 @@ func resourceAlert() *schema.Resource {
 				Optional:         true,
 				StateFunc:        trimSpaces,
 				DiffSuppressFunc: suppressAlertConditionOnType,
+				ValidateDiagFunc: validateQuery,
 			},
 			conditionsKey: {
-				Type:     schema.TypeMap,
-				Optional: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateQueries,
 			},
 			thresholdTargetsKey: {
 				Type:     schema.TypeMap,
@@ -61,6 +68,7 @@ func resourceAlert() *schema.Resource {
 				Optional:         true,
 				StateFunc:        trimSpaces,
 				DiffSuppressFunc: suppressSpaces,
+				ValidateDiagFunc: validateQuery,
 			},
 			minutesKey: {
 				Type:     schema.TypeInt,
@@ -84,10 +92,12 @@ func resourceAlert() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			tagsAllKey: tagsAllSchema(),
 			canViewKey: {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
 			},
 			canModifyKey: {
 				Type:     schema.TypeSet,
@@ -151,10 +161,10 @@ func suppressAlertConditionOnType(k, old, new string, d *schema.ResourceData) bo
 	return suppressSpaces(k, old, new, d)
 }
 
-func resourceAlertCreate(d *schema.ResourceData, meta interface{}) error {
-	alerts := meta.(*wavefrontClient).client.Alerts()
+func resourceAlertCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
 
-	tags := decodeTags(d)
+	tags := resourceTags(d, meta)
 	runbookLinks := decodeRunbookLinks(d.Get(runbookLinksKey).([]interface{}))
 	alertTriageDashboards := decodeAlertTriageDashboards(d.Get(alertTriageDashboardsKey).([]interface{}))
 
@@ -173,13 +183,13 @@ func resourceAlertCreate(d *schema.ResourceData, meta interface{}) error {
 
 	err := validateAlertConditions(a, d)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	// Create the alert on Wavefront
 	err = alerts.Create(a)
 	if err != nil {
-		return fmt.Errorf("error creating Alert %s. %s", d.Get(nameKey), err)
+		return diag.Errorf("error creating Alert %s. %s", d.Get(nameKey), err)
 	}
 
 	d.SetId(*a.ID)
@@ -188,14 +198,14 @@ func resourceAlertCreate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChanges(canViewKey, canModifyKey) {
 		err = alerts.SetACL(*a.ID, canView, canModify)
 		if err != nil {
-			return fmt.Errorf("error setting ACL on Alert %s. %s", d.Get(nameKey), err)
+			return diag.Errorf("error setting ACL on Alert %s. %s", d.Get(nameKey), err)
 		}
 	}
 	return nil
 }
 
-func resourceAlertRead(d *schema.ResourceData, meta interface{}) error {
-	alerts := meta.(*wavefrontClient).client.Alerts()
+func resourceAlertRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
 
 	alertID := d.Id()
 	tmpAlert := wavefront.Alert{ID: &alertID}
@@ -205,7 +215,7 @@ func resourceAlertRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
 	}
 
 	// Use the Wavefront ID as the Terraform ID
@@ -223,7 +233,9 @@ func resourceAlertRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set(minutesKey, tmpAlert.Minutes)
 	d.Set(resolveAfterMinutesKey, tmpAlert.ResolveAfterMinutes)
 	d.Set(notificationResendFrequencyMinutesKey, tmpAlert.NotificationResendFrequencyMinutes)
-	d.Set(tagsKey, tmpAlert.Tags)
+	if err = setResourceTags(d, meta, tmpAlert.Tags); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set(alertTypeKey, tmpAlert.AlertType)
 	d.Set(conditionsKey, tmpAlert.Conditions)
 	d.Set(thresholdTargetsKey, tmpAlert.Targets)
@@ -236,8 +248,8 @@ func resourceAlertRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceAlertUpdate(d *schema.ResourceData, meta interface{}) error {
-	alerts := meta.(*wavefrontClient).client.Alerts()
+func resourceAlertUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
 
 	alertID := d.Id()
 	tmpAlert := wavefront.Alert{ID: &alertID}
@@ -248,7 +260,7 @@ func resourceAlertUpdate(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	tags := decodeTags(d)
+	tags := resourceTags(d, meta)
 	runbookLinks := decodeRunbookLinks(d.Get(runbookLinksKey).([]interface{}))
 	alertTriageDashboards := decodeAlertTriageDashboards(d.Get(alertTriageDashboardsKey).([]interface{}))
 	canView, canModify := decodeAccessControlList(d)
@@ -267,41 +279,41 @@ func resourceAlertUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	err = validateAlertConditions(&a, d)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 
 	// Update the alert on Wavefront
 	err = alerts.Update(&a)
 	if err != nil {
-		return fmt.Errorf("error Updating Alert %s. %s", d.Get(nameKey), err)
+		return diag.Errorf("error Updating Alert %s. %s", d.Get(nameKey), err)
 	}
 
 	// Update the ACLs on the alert in Wavefront
 	if d.HasChanges(canViewKey, canModifyKey) {
 		err = alerts.SetACL(*a.ID, canView, canModify)
 		if err != nil {
-			return fmt.Errorf("error updating ACLs on Alert %s. %s", d.Get(nameKey), err)
+			return diag.Errorf("error updating ACLs on Alert %s. %s", d.Get(nameKey), err)
 		}
 	}
 
 	return nil
 }
 
-func resourceAlertDelete(d *schema.ResourceData, meta interface{}) error {
-	alerts := meta.(*wavefrontClient).client.Alerts()
+func resourceAlertDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
 
 	alertID := d.Id()
 	tmpAlert := wavefront.Alert{ID: &alertID}
 	err := alerts.Get(&tmpAlert)
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
 	}
 	a := tmpAlert
 
 	// Delete the Alert
 	err = alerts.Delete(&a, true)
 	if err != nil {
-		return fmt.Errorf("failed to delete Alert %s. %s", d.Id(), err)
+		return diag.Errorf("failed to delete Alert %s. %s", d.Id(), err)
 	}
 	d.SetId("")
 	return nil
@@ -320,32 +332,35 @@ func validateAlertConditions(a *wavefront.Alert, d *schema.ResourceData) error {
 			a.Conditions = trimSpacesMap(conditions.(map[string]interface{}))
 			err := validateThresholdLevels(a.Conditions)
 			if err != nil {
-				return err
+				return &attributeError{path: cty.GetAttrPath(conditionsKey), err: err}
 			}
 		} else {
-			return fmt.Errorf("conditions must be supplied for threshold alerts")
+			return newAttributeError(conditionsKey, "conditions must be supplied for threshold alerts")
 		}
 
 		if targets, ok := d.GetOk(thresholdTargetsKey); ok {
 			a.Targets = trimSpacesMap(targets.(map[string]interface{}))
-			return validateThresholdLevels(a.Targets)
+			err := validateThresholdLevels(a.Targets)
+			if err != nil {
+				return &attributeError{path: cty.GetAttrPath(thresholdTargetsKey), err: err}
+			}
 		}
 
 	} else if alertType == wavefront.AlertTypeClassic {
 		a.AlertType = wavefront.AlertTypeClassic
 
 		if d.Get(conditionKey) == "" {
-			return fmt.Errorf("condition must be supplied for classic alerts")
+			return newAttributeError(conditionKey, "condition must be supplied for classic alerts")
 		}
 		a.Condition = trimSpaces(d.Get(conditionKey).(string))
 
 		if d.Get(severityKey) == "" {
-			return fmt.Errorf("severity must be supplied for classic alerts")
+			return newAttributeError(severityKey, "severity must be supplied for classic alerts")
 		}
 		a.Severity = d.Get(severityKey).(string)
 		a.Target = d.Get(targetKey).(string)
 	} else {
-		return fmt.Errorf("alert_type must be CLASSIC or THRESHOLD")
+		return newAttributeError(alertTypeKey, "alert_type must be CLASSIC or THRESHOLD")
 	}
 
 	return nil
@@ -0,0 +1,104 @@
+package wavefront
+
+import (
+	"context"
+	"log"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const alertIDKey = "alert_id"
+
+// resourceAlertACL manages the access control list of an alert, so that it
+// can be managed apart from the alert. The can_view and can_modify of the
+// wavefront_alert must then be left unset.
+func resourceAlertACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAlertACLSet,
+		ReadContext:   resourceAlertACLRead,
+		UpdateContext: resourceAlertACLSet,
+		DeleteContext: resourceAlertACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema:   aclResourceSchema(alertIDKey),
+	}
+}
+
+func resourceAlertACLSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	alertID := d.Get(alertIDKey).(string)
+	canView, canModify := decodeAccessControlList(d)
+
+	err := alerts.SetACL(alertID, canView, canModify)
+	if err != nil {
+		return diag.Errorf("error setting ACL on Alert %s. %s", alertID, err)
+	}
+	d.SetId(alertID)
+	log.Printf("[INFO] Set the ACL of Wavefront Alert %s", d.Id())
+
+	return resourceAlertACLRead(ctx, d, meta)
+}
+
+func resourceAlertACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	alertID := d.Id()
+	alert := wavefront.Alert{ID: &alertID}
+	err := alerts.Get(&alert)
+	if err != nil {
+		if wavefront.NotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
+	}
+
+	return diag.FromErr(setAccessControlList(d, alertIDKey, alertID, alert.ACL))
+}
+
+// resourceAlertACLDelete clears the access control list of the alert, so
+// that it's visible to and modifiable by everyone again.
+func resourceAlertACLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	err := alerts.SetACL(d.Id(), []string{}, []string{})
+	if err != nil && !wavefront.NotFound(err) {
+		return diag.Errorf("error clearing ACL on Alert %s. %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// aclResourceSchema returns the schema of a resource managing the access
+// control list of the entity with the ID in idKey.
+func aclResourceSchema(idKey string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		idKey: {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		canViewKey: {
+			Type:     schema.TypeSet,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		canModifyKey: {
+			Type:     schema.TypeSet,
+			Required: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+}
+
+func setAccessControlList(d *schema.ResourceData, idKey, id string, acl wavefront.AccessControlList) error {
+	if err := d.Set(idKey, id); err != nil {
+		return err
+	}
+	if err := d.Set(canViewKey, acl.CanView); err != nil {
+		return err
+	}
+	return d.Set(canModifyKey, acl.CanModify)
+}
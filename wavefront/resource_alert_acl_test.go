@@ -0,0 +1,111 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertACL_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+
+	alert := &wavefront.Alert{Name: "ACL Alert", Condition: "ts(cpu) > 1", Minutes: 5}
+	require.NoError(t, client.Alerts().Create(alert))
+
+	alertACL := resourceAlertACL()
+	d := schema.TestResourceDataRaw(t, alertACL.Schema, map[string]interface{}{
+		alertIDKey:   *alert.ID,
+		canViewKey:   []interface{}{"viewers"},
+		canModifyKey: []interface{}{"security"},
+	})
+	diags := alertACL.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, *alert.ID, d.Id())
+
+	require.NoError(t, client.Alerts().Get(alert))
+	assert.Equal(t, []string{"viewers"}, alert.ACL.CanView)
+	assert.Equal(t, []string{"security"}, alert.ACL.CanModify)
+
+	// the alert updated by the team managing its content
+	alert.Minutes = 10
+	require.NoError(t, client.Alerts().Update(alert))
+	diags = alertACL.ReadContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"security"}, getStringSlice(d, canModifyKey), "the ACL must survive updates of the alert")
+
+	diags = alertACL.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NoError(t, client.Alerts().Get(alert))
+	assert.True(t, isEmptyACL(alert.ACL), "%v", alert.ACL)
+
+	alertID := *alert.ID
+	require.NoError(t, client.Alerts().Delete(alert, true))
+	d.SetId(alertID)
+	diags = alertACL.ReadContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, d.Id(), "the ACL of a deleted alert must be removed from the state")
+}
+
+func TestAccWavefrontAlertACL_Basic(t *testing.T) {
+	resourceName := "wavefront_alert_acl.acl"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertACL(5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "alert_id", "wavefront_alert.alert", "id"),
+					resource.TestCheckResourceAttr(resourceName, "can_view.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "can_modify.#", "1"),
+				),
+			},
+			{
+				// updating the alert leaves the ACL alone, and the alert doesn't
+				// plan to reset its ACL
+				Config: testAccCheckWavefrontAlertACL(10),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("wavefront_alert.alert", "minutes", "10"),
+					resource.TestCheckResourceAttr(resourceName, "can_modify.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontAlertACL(minutes int) string {
+	return fmt.Sprintf(`
+resource "wavefront_user" "owner" {
+  email = "alert-acl+tftesting@example.com"
+}
+
+resource "wavefront_alert" "alert" {
+  name                  = "Terraform Test Alert ACL"
+  target                = "test@example.com"
+  condition             = "ts(\"cpu.usage_idle\") < 10"
+  minutes               = %d
+  resolve_after_minutes = 5
+  severity              = "WARN"
+  tags                  = ["terraform"]
+}
+
+resource "wavefront_alert_acl" "acl" {
+  alert_id   = wavefront_alert.alert.id
+  can_view   = [wavefront_user.owner.id]
+  can_modify = [wavefront_user.owner.id]
+}
+`, minutes)
+}
@@ -0,0 +1,351 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/wql"
+)
+
+const alertJSONKey = "alert_json"
+
+func resourceAlertJSON() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAlertJSONCreate,
+		ReadContext:   resourceAlertJSONRead,
+		UpdateContext: resourceAlertJSONUpdate,
+		DeleteContext: resourceAlertJSONDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+
+		Schema: map[string]*schema.Schema{
+			alertJSONKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: ValidateAlertJSON,
+				StateFunc:    NormalizeAlertJSON,
+			},
+		},
+	}
+}
+
+// alertJSONFields are the keys of the fields of wavefront.Alert in alert
+// JSON. Its tags are in the "customerTags" of "tags".
+var alertJSONFields = func() map[string]bool {
+	fields := map[string]bool{"tags": true}
+	t := reflect.TypeOf(wavefront.Alert{})
+	for i := 0; i < t.NumField(); i++ {
+		if name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// ignoredAlertJSONKeys are the keys of the alert JSON exported by Wavefront
+// which wavefront.Alert doesn't have, but which are managed by Wavefront or
+// only keep the state of the query builder of the UI, so can be ignored.
+var ignoredAlertJSONKeys = map[string]bool{
+	"activeMaintenanceWindows":         true,
+	"conditionQBEnabled":               true,
+	"conditionQBSerialization":         true,
+	"createUserId":                     true,
+	"created":                          true,
+	"createdEpochMillis":               true,
+	"creatorId":                        true,
+	"deleted":                          true,
+	"displayExpressionQBEnabled":       true,
+	"displayExpressionQBSerialization": true,
+	"event":                            true,
+	"hidden":                           true,
+	"hostsUsed":                        true,
+	"inTrash":                          true,
+	"lastErrorMessage":                 true,
+	"lastEventTime":                    true,
+	"lastFailedTime":                   true,
+	"lastNotificationMillis":           true,
+	"lastProcessedMillis":              true,
+	"lastQueryTime":                    true,
+	"metricsUsed":                      true,
+	"noDataEvent":                      true,
+	"notificants":                      true,
+	"numPointsInFailureFrame":          true,
+	"orphan":                           true,
+	"pointsScannedAtLastQuery":         true,
+	"prefiringHostLabelPairs":          true,
+	"queryFailing":                     true,
+	"snoozed":                          true,
+	"systemOwned":                      true,
+	"targetInfo":                       true,
+	"updateUserId":                     true,
+	"updated":                          true,
+	"updatedEpochMillis":               true,
+	"updaterId":                        true,
+}
+
+// unsupportedAlertJSONKeys returns the sorted keys of the alert JSON which
+// wavefront.Alert doesn't have and which aren't ignored. Their values would
+// be lost, so alert JSON with such keys is invalid.
+func unsupportedAlertJSONKeys(alertJSON string) ([]string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(alertJSON), &raw); err != nil {
+		return nil, err
+	}
+	var unsupported []string
+	for key := range raw {
+		if !alertJSONFields[key] && !ignoredAlertJSONKeys[key] {
+			unsupported = append(unsupported, key)
+		}
+	}
+	var tags map[string]json.RawMessage
+	if err := json.Unmarshal(raw["tags"], &tags); err == nil {
+		for key := range tags {
+			if key != "customerTags" {
+				unsupported = append(unsupported, "tags."+key)
+			}
+		}
+	}
+	sort.Strings(unsupported)
+	return unsupported, nil
+}
+
+// parseAlertJSON parses alert JSON as NormalizeAlertJSON normalizes it.
+func parseAlertJSON(alertJSON string) (*wavefront.Alert, error) {
+	unsupported, err := unsupportedAlertJSONKeys(alertJSON)
+	if err != nil {
+		return nil, err
+	}
+	if len(unsupported) > 0 {
+		return nil, fmt.Errorf("unsupported keys %s, which wavefront_alert_json can't manage",
+			strings.Join(unsupported, ", "))
+	}
+	var alert wavefront.Alert
+	if err = json.Unmarshal([]byte(alertJSON), &alert); err != nil {
+		return nil, err
+	}
+
+	// remove keys which are managed by Wavefront
+	alert.ID = nil
+	alert.Status = nil
+	alert.SeverityList = nil
+	alert.FailingHostLabelPairs = nil
+	alert.InMaintenanceHostLabelPairs = nil
+
+	if alert.AlertType == "" {
+		alert.AlertType = wavefront.AlertTypeClassic
+	}
+	// Wavefront sets the condition of threshold alerts to their display
+	// expression, and their severity to the highest of their conditions.
+	if strings.EqualFold(alert.AlertType, wavefront.AlertTypeThreshold) {
+		alert.Condition = alert.DisplayExpression
+		alert.Severity = ""
+	}
+	alert.Condition = strings.TrimSpace(alert.Condition)
+	alert.DisplayExpression = strings.TrimSpace(alert.DisplayExpression)
+
+	// Wavefront doesn't keep the order of tags and access control lists
+	sort.Strings(alert.Tags)
+	sort.Strings(alert.ACL.CanView)
+	sort.Strings(alert.ACL.CanModify)
+
+	// missing and empty lists and maps are the same
+	if len(alert.Tags) == 0 {
+		alert.Tags = nil
+	}
+	if len(alert.ACL.CanView) == 0 {
+		alert.ACL.CanView = nil
+	}
+	if len(alert.ACL.CanModify) == 0 {
+		alert.ACL.CanModify = nil
+	}
+	if len(alert.Conditions) == 0 {
+		alert.Conditions = nil
+	}
+	if len(alert.Targets) == 0 {
+		alert.Targets = nil
+	}
+	if len(alert.RunbookLinks) == 0 {
+		alert.RunbookLinks = nil
+	}
+	if len(alert.AlertTriageDashboards) == 0 {
+		alert.AlertTriageDashboards = nil
+	}
+	return &alert, nil
+}
+
+func resourceAlertJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	alertID := d.Id()
+	alert := wavefront.Alert{ID: &alertID}
+	err := alerts.Get(&alert)
+	if err != nil {
+		if wavefront.NotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
+	}
+
+	// The access control list is only managed when alert_json sets one, so
+	// keep it out of the state otherwise, unless the alert is being imported.
+	if previous := d.Get(alertJSONKey).(string); previous != "" {
+		if configured, parseErr := parseAlertJSON(previous); parseErr == nil && isEmptyACL(configured.ACL) {
+			alert.ACL = wavefront.AccessControlList{}
+		}
+	}
+
+	bytes, err := json.Marshal(&alert)
+	if err != nil {
+		return diag.Errorf("failed to encode Wavefront Alert %s. %s", d.Id(), err)
+	}
+	err = d.Set(alertJSONKey, NormalizeAlertJSON(string(bytes)))
+	if err != nil {
+		return diag.Errorf("failed to set alert json %s. %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceAlertJSONCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	// json is already validated during resource Validation
+	alert, err := parseAlertJSON(d.Get(alertJSONKey).(string))
+	if err != nil {
+		return diag.Errorf("failed to parse alert, %s", err)
+	}
+	log.Printf("[INFO] Create Wavefront Alert %s", alert.Name)
+
+	acl := alert.ACL
+	err = alerts.Create(alert)
+	if err != nil {
+		return diag.Errorf("error creating Alert %s. %s", alert.Name, err)
+	}
+	d.SetId(*alert.ID)
+	log.Printf("[INFO] Wavefront Alert %s Created", d.Id())
+
+	if !isEmptyACL(acl) {
+		err = alerts.SetACL(d.Id(), acl.CanView, acl.CanModify)
+		if err != nil {
+			return diag.Errorf("error setting ACL on Alert %s. %s", alert.Name, err)
+		}
+	}
+	return resourceAlertJSONRead(ctx, d, meta)
+}
+
+func resourceAlertJSONUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	previousJSON, alertJSON := d.GetChange(alertJSONKey)
+	alert, err := parseAlertJSON(alertJSON.(string))
+	if err != nil {
+		return diag.Errorf("failed to parse alert, %s", err)
+	}
+	log.Printf("[INFO] Update Wavefront Alert %s", d.Id())
+
+	acl := alert.ACL
+	alertID := d.Id()
+	alert.ID = &alertID
+	err = alerts.Update(alert)
+	if err != nil {
+		return diag.Errorf("error Updating Alert %s. %s", alert.Name, err)
+	}
+
+	previous, err := parseAlertJSON(previousJSON.(string))
+	if err != nil || !reflect.DeepEqual(previous.ACL, acl) {
+		err = alerts.SetACL(d.Id(), acl.CanView, acl.CanModify)
+		if err != nil {
+			return diag.Errorf("error updating ACLs on Alert %s. %s", alert.Name, err)
+		}
+	}
+	return resourceAlertJSONRead(ctx, d, meta)
+}
+
+func resourceAlertJSONDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	alerts := meta.(*wavefrontClient).withContext(ctx).Alerts()
+	alertID := d.Id()
+	alert := wavefront.Alert{ID: &alertID}
+
+	// Delete the alert, skipping the trash
+	err := alerts.Delete(&alert, true)
+	if err != nil && !wavefront.NotFound(err) {
+		return diag.Errorf("error deleting Alert %s. %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func isEmptyACL(acl wavefront.AccessControlList) bool {
+	return len(acl.CanView) == 0 && len(acl.CanModify) == 0
+}
+
+// ValidateAlertJSON validates alert JSON: its fields must have the types of
+// the fields of Wavefront alerts, it must have a name and the conditions its
+// alert type requires, and its queries must parse.
+func ValidateAlertJSON(val interface{}, _ string) ([]string, []error) {
+	alert, err := parseAlertJSON(val.(string))
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	var errs []error
+	if alert.Name == "" {
+		errs = append(errs, fmt.Errorf("name must be supplied"))
+	}
+	switch strings.ToUpper(alert.AlertType) {
+	case wavefront.AlertTypeClassic:
+		if alert.Condition == "" {
+			errs = append(errs, fmt.Errorf("condition must be supplied for classic alerts"))
+		}
+	case wavefront.AlertTypeThreshold:
+		if len(alert.Conditions) == 0 {
+			errs = append(errs, fmt.Errorf("conditions must be supplied for threshold alerts"))
+		}
+		for _, m := range []map[string]string{alert.Conditions, alert.Targets} {
+			if err = validateThresholdLevels(m); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	default:
+		errs = append(errs, fmt.Errorf("alertType must be either CLASSIC or THRESHOLD, got %s", alert.AlertType))
+	}
+
+	queries := map[string]string{"condition": alert.Condition, "displayExpression": alert.DisplayExpression}
+	for severity, condition := range alert.Conditions {
+		queries["conditions."+severity] = condition
+	}
+	fields := make([]string, 0, len(queries))
+	for field := range queries {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if queries[field] == "" {
+			continue
+		}
+		if _, err = wql.Parse(queries[field]); err != nil {
+			errs = append(errs, fmt.Errorf("invalid query in %s, %s", field, err))
+		}
+	}
+	return nil, errs
+}
+
+// NormalizeAlertJSON normalizes alert JSON so that it only differs from the
+// JSON of the alert in Wavefront on meaningful changes: the fields managed
+// by Wavefront, such as its ID, status and timestamps, are removed, and the
+// fields missing are set to their zero values.
+func NormalizeAlertJSON(val interface{}) string {
+	alert, err := parseAlertJSON(val.(string))
+	if err != nil {
+		return val.(string)
+	}
+	ret, _ := json.Marshal(alert)
+	return string(ret)
+}
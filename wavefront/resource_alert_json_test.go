@@ -0,0 +1,252 @@
+package wavefront
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeAlertJSON(t *testing.T) {
+	fromServer := `{
+		"id": "1700000000001",
+		"name": "CPU",
+		"alertType": "CLASSIC",
+		"condition": "ts(cpu.usage) > 90 ",
+		"severity": "WARN",
+		"minutes": 5,
+		"status": ["CHECKING"],
+		"severityList": ["WARN"],
+		"tags": {"customerTags": ["team.infra", "env.prod"]},
+		"createdEpochMillis": 1700000000000,
+		"updatedEpochMillis": 1700000000001,
+		"creatorId": "jane@example.com",
+		"lastProcessedMillis": 1700000000002,
+		"failingHostLabelPairs": [{"host": "web-01", "firing": 1}]
+	}`
+	fromConfig := `{
+		"name": "CPU",
+		"condition": "ts(cpu.usage) > 90",
+		"severity": "WARN",
+		"minutes": 5,
+		"tags": {"customerTags": ["env.prod", "team.infra"]},
+		"runbookLinks": []
+	}`
+	assert.Equal(t, NormalizeAlertJSON(fromConfig), NormalizeAlertJSON(fromServer))
+	assert.NotContains(t, NormalizeAlertJSON(fromServer), "1700000000001")
+
+	changed := `{"name": "CPU", "condition": "ts(cpu.usage) > 95", "severity": "WARN", "minutes": 5,
+		"tags": {"customerTags": ["env.prod", "team.infra"]}}`
+	assert.NotEqual(t, NormalizeAlertJSON(fromConfig), NormalizeAlertJSON(changed))
+
+	threshold := `{"name": "CPU", "alertType": "THRESHOLD", "displayExpression": "ts(cpu.usage)",
+		"conditions": {"warn": "ts(cpu.usage) > 90"}, "minutes": 5}`
+	thresholdFromServer := `{"name": "CPU", "alertType": "THRESHOLD", "displayExpression": "ts(cpu.usage)",
+		"condition": "ts(cpu.usage)", "severity": "WARN", "conditions": {"warn": "ts(cpu.usage) > 90"}, "minutes": 5}`
+	assert.Equal(t, NormalizeAlertJSON(threshold), NormalizeAlertJSON(thresholdFromServer))
+
+	assert.Equal(t, "not json", NormalizeAlertJSON("not json"))
+}
+
+func TestValidateAlertJSON(t *testing.T) {
+	cases := []struct {
+		alertJSON string
+		errors    []string
+	}{
+		{`{"name": "CPU", "condition": "ts(cpu.usage) > 90", "severity": "WARN", "minutes": 5}`, nil},
+		{`{"name": "CPU", "alertType": "THRESHOLD", "displayExpression": "ts(cpu.usage)",
+			"conditions": {"warn": "ts(cpu.usage) > 90"}, "targets": {"warn": "target:abc"}}`, nil},
+		{`{"name": "CPU"`, []string{"unexpected end of JSON input"}},
+		{`{"name": "CPU", "minutes": "5"}`, []string{"cannot unmarshal string"}},
+		{`{"condition": "ts(cpu.usage) > 90"}`, []string{"name must be supplied"}},
+		{`{"name": "CPU"}`, []string{"condition must be supplied for classic alerts"}},
+		{`{"name": "CPU", "alertType": "THRESHOLD"}`, []string{"conditions must be supplied for threshold alerts"}},
+		{`{"name": "CPU", "alertType": "THRESHOLD", "conditions": {"critical": "ts(cpu.usage) > 90"}}`,
+			[]string{"invalid severity: critical"}},
+		{`{"name": "CPU", "alertType": "OTHER"}`, []string{"alertType must be either CLASSIC or THRESHOLD, got OTHER"}},
+		{`{"name": "CPU", "condition": "ts(cpu.usage) > 90", "alertSources": [], "tags": {"other": []}}`,
+			[]string{"unsupported keys alertSources, tags.other, which wavefront_alert_json can't manage"}},
+		{`{"name": "CPU", "condition": "ts(cpu.usage) >"}`,
+			[]string{"invalid query in condition, column 16: unexpected end of query, expected an expression"}},
+	}
+	for _, c := range cases {
+		_, errs := ValidateAlertJSON(c.alertJSON, alertJSONKey)
+		assert.Len(t, errs, len(c.errors), c.alertJSON)
+		for i, err := range errs {
+			if i < len(c.errors) {
+				assert.Contains(t, err.Error(), c.errors[i], c.alertJSON)
+			}
+		}
+	}
+}
+
+func TestAccWavefrontAlertJSON_Basic(t *testing.T) {
+	var record wavefront.Alert
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertJSONBasic("Terraform Test Alert JSON", 80),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontAlertJSONExists("wavefront_alert_json.test_alert_json", &record),
+					testAccCheckWavefrontAlertJSONAttributes(&record, "Terraform Test Alert JSON",
+						"100-ts(\"cpu.usage_idle\", environment=preprod) > 80"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWavefrontAlertJSON_Updated(t *testing.T) {
+	var record wavefront.Alert
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertJSONBasic("Terraform Test Alert JSON", 80),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontAlertJSONExists("wavefront_alert_json.test_alert_json", &record),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontAlertJSONBasic("Terraform Test Alert JSON Updated", 90),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontAlertJSONExists("wavefront_alert_json.test_alert_json", &record),
+					testAccCheckWavefrontAlertJSONAttributes(&record, "Terraform Test Alert JSON Updated",
+						"100-ts(\"cpu.usage_idle\", environment=preprod) > 90"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWavefrontAlertJSON_Threshold(t *testing.T) {
+	var record wavefront.Alert
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertJSONThreshold(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontAlertJSONExists("wavefront_alert_json.test_threshold_alert_json", &record),
+					testAccCheckWavefrontThresholdAlertAttributes(&record),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontAlertJSONDestroy(s *terraform.State) error {
+	alerts := testAccProvider.Meta().(*wavefrontClient).client.Alerts()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "wavefront_alert_json" {
+			continue
+		}
+		tmpAlert := wavefront.Alert{ID: &rs.Primary.ID}
+		err := alerts.Get(&tmpAlert)
+		if err == nil {
+			return fmt.Errorf("alert still exists")
+		}
+	}
+	return nil
+}
+
+func testAccCheckWavefrontAlertJSONExists(n string, alert *wavefront.Alert) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no Record ID is set")
+		}
+		alerts := testAccProvider.Meta().(*wavefrontClient).client.Alerts()
+		tmpAlert := wavefront.Alert{ID: &rs.Primary.ID}
+		err := alerts.Get(&tmpAlert)
+		if err != nil {
+			return fmt.Errorf("error finding Wavefront Alert %s", err)
+		}
+		*alert = tmpAlert
+		return nil
+	}
+}
+
+func testAccCheckWavefrontAlertJSONAttributes(alert *wavefront.Alert, name, condition string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if alert.Name != name {
+			return fmt.Errorf("bad value: %s", alert.Name)
+		}
+		if alert.Condition != condition {
+			return fmt.Errorf("bad value: %s", alert.Condition)
+		}
+		return nil
+	}
+}
+
+func testAccCheckWavefrontAlertJSONBasic(name string, threshold int) string {
+	return fmt.Sprintf(`
+resource "wavefront_alert_json" "test_alert_json" {
+  alert_json = <<-EOF
+    {
+      "name": %q,
+      "alertType": "CLASSIC",
+      "target": "test@example.com",
+      "condition": "100-ts(\"cpu.usage_idle\", environment=preprod) > %d",
+      "displayExpression": "100-ts(\"cpu.usage_idle\", environment=preprod)",
+      "minutes": 5,
+      "resolveAfterMinutes": 5,
+      "severity": "WARN",
+      "tags": {
+        "customerTags": ["terraform", "test"]
+      }
+    }
+  EOF
+}
+`, name, threshold)
+}
+
+func testAccCheckWavefrontAlertJSONThreshold() string {
+	return `
+resource "wavefront_alert_target" "test_target" {
+  name = "Terraform Test Target Alert JSON"
+  description = "Test target"
+  method = "EMAIL"
+  recipient = "test@example.com"
+  email_subject = "This is a test"
+  is_html_content = true
+  template = "{}"
+  triggers = [
+    "ALERT_OPENED",
+    "ALERT_RESOLVED"
+  ]
+}
+
+resource "wavefront_alert_json" "test_threshold_alert_json" {
+  alert_json = jsonencode({
+    name              = "Terraform Test Threshold Alert JSON"
+    alertType         = "THRESHOLD"
+    displayExpression = "100-ts(\"cpu.usage_idle\", environment=preprod and cpu=cpu-total )"
+    minutes           = 5
+    conditions = {
+      severe = "100-ts(\"cpu.usage_idle\", environment=preprod and cpu=cpu-total ) > 80"
+      warn   = "100-ts(\"cpu.usage_idle\", environment=preprod and cpu=cpu-total ) > 60"
+    }
+    targets = {
+      severe = "target:${wavefront_alert_target.test_target.id}"
+    }
+    tags = {
+      customerTags = ["terraform"]
+    }
+  })
+}
+`
+}
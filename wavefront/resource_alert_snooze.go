@@ -0,0 +1,170 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	untilTimeInSecondsKey = "until_time_in_seconds"
+	snoozedKey            = "snoozed"
+)
+
+func resourceAlertSnooze() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAlertSnoozeCreate,
+		ReadContext:   resourceAlertSnoozeRead,
+		DeleteContext: resourceAlertSnoozeDelete,
+		Timeouts:      resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			alertIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			untilTimeInSecondsKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			snoozedKey: {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// alertSnooze is the snooze of an alert.
+type alertSnooze struct {
+	// Snoozed is when the alert is snoozed until in milliseconds since the
+	// epoch, -1 when it's snoozed indefinitely, or 0 when it isn't snoozed.
+	Snoozed int64 `json:"snoozed"`
+}
+
+// active returns whether the alert is snoozed at now.
+func (s alertSnooze) active(now time.Time) bool {
+	return s.Snoozed == -1 || s.Snoozed > now.UnixMilli()
+}
+
+// untilTimeInSeconds returns when the alert is snoozed until in seconds since
+// the epoch, or 0 when it's snoozed indefinitely or not snoozed.
+func (s alertSnooze) untilTimeInSeconds() int64 {
+	if s.Snoozed <= 0 {
+		return 0
+	}
+	return s.Snoozed / 1000
+}
+
+// doAlertRequest sends a request for the alert with the given ID, decoding
+// its snooze from the alert in the response. wavefront.Alerts neither has
+// the snooze of alerts nor snoozes them, so the alert is requested here.
+func doAlertRequest(
+	client *wavefront.Client, method, alertID, action string, params *map[string]string,
+) (alertSnooze, error) {
+	path := "alert/" + url.PathEscape(alertID)
+	if action != "" {
+		path += "/" + action
+	}
+	req, err := client.NewRequest(method, path, params, nil)
+	if err != nil {
+		return alertSnooze{}, err
+	}
+	body, err := client.Do(req)
+	if err != nil {
+		return alertSnooze{}, err
+	}
+	defer body.Close()
+
+	var resp struct {
+		Response alertSnooze `json:"response"`
+	}
+	if err = json.NewDecoder(body).Decode(&resp); err != nil {
+		return alertSnooze{}, fmt.Errorf("error parsing Wavefront Alert %s, %s", alertID, err)
+	}
+	return resp.Response, nil
+}
+
+// getAlertSnooze returns the snooze of the alert with the given ID.
+func getAlertSnooze(client *wavefront.Client, alertID string) (alertSnooze, error) {
+	return doAlertRequest(client, http.MethodGet, alertID, "", nil)
+}
+
+// resourceAlertSnoozeCreate refuses to snooze an alert which is already
+// snoozed, as the snoozes of an alert, identified by the alert, would unsnooze
+// the alert for each other.
+func resourceAlertSnoozeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	alertID := d.Get(alertIDKey).(string)
+
+	wfMutexKV.Lock("alert_snooze_" + alertID)
+	defer wfMutexKV.Unlock("alert_snooze_" + alertID)
+
+	current, err := getAlertSnooze(client, alertID)
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Alert %s. %s", alertID, err)
+	}
+	if current.active(time.Now()) {
+		return diag.Errorf(
+			"Wavefront Alert %s is already snoozed, by another wavefront_alert_snooze or outside of Terraform", alertID)
+	}
+
+	var params *map[string]string
+	if until, ok := d.GetOk(untilTimeInSecondsKey); ok {
+		seconds := int64(until.(int)) - time.Now().Unix()
+		if seconds <= 0 {
+			return diag.Errorf("%s %d is in the past", untilTimeInSecondsKey, until.(int))
+		}
+		params = &map[string]string{"seconds": strconv.FormatInt(seconds, 10)}
+	}
+	if _, err = doAlertRequest(client, http.MethodPost, alertID, "snooze", params); err != nil {
+		return diag.Errorf("error snoozing Wavefront Alert %s. %s", alertID, err)
+	}
+	d.SetId(alertID)
+	return resourceAlertSnoozeRead(ctx, d, meta)
+}
+
+func resourceAlertSnoozeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	snooze, err := getAlertSnooze(client, d.Id())
+	if wavefront.NotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Alert %s. %s", d.Id(), err)
+	}
+
+	now := time.Now()
+	snoozed := snooze.active(now)
+	until := int64(d.Get(untilTimeInSecondsKey).(int))
+	if !snoozed && (until == 0 || until > now.Unix()) {
+		// unsnoozed outside of Terraform before the snooze ended, so the
+		// resource is removed from the state for the next apply to snooze
+		// the alert again.
+		d.SetId("")
+		return nil
+	}
+	return diag.FromErr(d.Set(snoozedKey, snoozed))
+}
+
+func resourceAlertSnoozeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	_, err := doAlertRequest(client, http.MethodPost, d.Id(), "unsnooze", nil)
+	if err != nil && !wavefront.NotFound(err) {
+		return diag.Errorf("error unsnoozing Wavefront Alert %s. %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
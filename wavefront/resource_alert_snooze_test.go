@@ -0,0 +1,143 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertSnooze_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	ctx := context.Background()
+
+	alert := &wavefront.Alert{Name: "Snoozed Alert", Condition: "ts(cpu) > 1", Minutes: 5}
+	require.NoError(t, client.Alerts().Create(alert))
+	alertID := *alert.ID
+
+	status := dataSourceAlertSnooze()
+	readStatus := func() *schema.ResourceData {
+		s := schema.TestResourceDataRaw(t, status.Schema, map[string]interface{}{alertIDKey: alertID})
+		statusDiags := status.ReadContext(ctx, s, provider.Meta())
+		require.False(t, statusDiags.HasError(), "%v", statusDiags)
+		return s
+	}
+	assert.False(t, readStatus().Get(snoozedKey).(bool))
+
+	// snoozed indefinitely
+	alertSnooze := resourceAlertSnooze()
+	d := schema.TestResourceDataRaw(t, alertSnooze.Schema, map[string]interface{}{alertIDKey: alertID})
+	diags := alertSnooze.CreateContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, alertID, d.Id())
+	assert.True(t, d.Get(snoozedKey).(bool))
+	s := readStatus()
+	assert.True(t, s.Get(snoozedKey).(bool))
+	assert.Equal(t, 0, s.Get(untilTimeInSecondsKey))
+
+	// another snooze of the snoozed alert
+	other := schema.TestResourceDataRaw(t, alertSnooze.Schema, map[string]interface{}{alertIDKey: alertID})
+	diags = alertSnooze.CreateContext(ctx, other, provider.Meta())
+	assert.True(t, diags.HasError(), "snoozing a snoozed alert must fail")
+	assert.True(t, readStatus().Get(snoozedKey).(bool))
+
+	// updating the alert keeps it snoozed
+	alert.Minutes = 10
+	require.NoError(t, client.Alerts().Update(alert))
+	assert.True(t, readStatus().Get(snoozedKey).(bool))
+
+	// unsnoozed outside of Terraform
+	_, err := doAlertRequest(&client, http.MethodPost, alertID, "unsnooze", nil)
+	require.NoError(t, err)
+	diags = alertSnooze.ReadContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, d.Id(), "an alert unsnoozed before the end of the snooze must be snoozed again")
+
+	// snoozed until a time
+	until := time.Now().Add(time.Hour).Unix()
+	d = schema.TestResourceDataRaw(t, alertSnooze.Schema, map[string]interface{}{
+		alertIDKey:            alertID,
+		untilTimeInSecondsKey: int(until),
+	})
+	diags = alertSnooze.CreateContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	s = readStatus()
+	assert.True(t, s.Get(snoozedKey).(bool))
+	assert.InDelta(t, until, s.Get(untilTimeInSecondsKey), 2)
+
+	diags = alertSnooze.DeleteContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.False(t, readStatus().Get(snoozedKey).(bool))
+
+	past := schema.TestResourceDataRaw(t, alertSnooze.Schema, map[string]interface{}{
+		alertIDKey:            alertID,
+		untilTimeInSecondsKey: int(time.Now().Add(-time.Hour).Unix()),
+	})
+	diags = alertSnooze.CreateContext(ctx, past, provider.Meta())
+	assert.True(t, diags.HasError(), "snoozing until a past time must fail")
+}
+
+func TestAlertSnooze_Active(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	assert.False(t, alertSnooze{}.active(now))
+	assert.True(t, alertSnooze{Snoozed: -1}.active(now))
+	assert.True(t, alertSnooze{Snoozed: now.Add(time.Minute).UnixMilli()}.active(now))
+	assert.False(t, alertSnooze{Snoozed: now.Add(-time.Minute).UnixMilli()}.active(now), "the snooze ended")
+}
+
+func TestAccWavefrontAlertSnooze_Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontAlertDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontAlertSnooze(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"wavefront_alert_snooze.snooze", "alert_id", "wavefront_alert.alert", "id"),
+					resource.TestCheckResourceAttr("wavefront_alert_snooze.snooze", "snoozed", "true"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontAlertSnooze(fmt.Sprintf(
+					"until_time_in_seconds = %d", time.Now().Add(24*time.Hour).Unix())),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.wavefront_alert_snooze.status", "snoozed", "true"),
+					resource.TestCheckResourceAttrSet("data.wavefront_alert_snooze.status", "until_time_in_seconds"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontAlertSnooze(until string) string {
+	return fmt.Sprintf(`
+resource "wavefront_alert" "alert" {
+  name                  = "Terraform Test Alert Snooze"
+  target                = "test@example.com"
+  condition             = "ts(\"cpu.usage_idle\") < 10"
+  minutes               = 5
+  resolve_after_minutes = 5
+  severity              = "WARN"
+  tags                  = ["terraform"]
+}
+
+resource "wavefront_alert_snooze" "snooze" {
+  alert_id = wavefront_alert.alert.id
+  %s
+}
+
+data "wavefront_alert_snooze" "status" {
+  alert_id = wavefront_alert_snooze.snooze.alert_id
+}
+`, until)
+}
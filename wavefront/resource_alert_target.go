@@ -1,22 +1,25 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceTarget() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceTargetCreate,
-		Read:   resourceTargetRead,
-		Update: resourceTargetUpdate,
-		Delete: resourceTargetDelete,
+		CreateContext: resourceTargetCreate,
+		ReadContext:   resourceTargetRead,
+		UpdateContext: resourceTargetUpdate,
+		DeleteContext: resourceTargetDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -94,8 +97,8 @@ func resourceTarget() *schema.Resource {
 	}
 }
 
-func resourceTargetCreate(d *schema.ResourceData, meta interface{}) error {
-	targets := meta.(*wavefrontClient).client.Targets()
+func resourceTargetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targets := meta.(*wavefrontClient).withContext(ctx).Targets()
 
 	var triggers []string
 	for _, trigger := range d.Get("triggers").([]interface{}) {
@@ -126,16 +129,16 @@ func resourceTargetCreate(d *schema.ResourceData, meta interface{}) error {
 	// Create the Target on Wavefront
 	err := targets.Create(t)
 	if err != nil {
-		return fmt.Errorf("error Creating Target %s. %s", d.Get("name"), err)
+		return diag.Errorf("error Creating Target %s. %s", d.Get("name"), err)
 	}
 
 	d.SetId(*t.ID)
 
-	return resourceTargetRead(d, meta)
+	return resourceTargetRead(ctx, d, meta)
 }
 
-func resourceTargetRead(d *schema.ResourceData, meta interface{}) error {
-	targets := meta.(*wavefrontClient).client.Targets()
+func resourceTargetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targets := meta.(*wavefrontClient).withContext(ctx).Targets()
 
 	targetID := d.Id()
 	tmpTarget := wavefront.Target{ID: &targetID}
@@ -146,30 +149,42 @@ func resourceTargetRead(d *schema.ResourceData, meta interface{}) error {
 			return nil
 		}
 		d.SetId("")
-		return fmt.Errorf("error finding Wavefront Alert Target %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Alert Target %s. %s", d.Id(), err)
 	}
 
 	// Use the Wavefront ID as the Terraform ID
 	d.SetId(*tmpTarget.ID)
-	d.Set("name", tmpTarget.Title)
-	d.Set(descriptionKey, tmpTarget.Description)
-	d.Set("triggers", tmpTarget.Triggers)
-	d.Set("template", tmpTarget.Template)
-	d.Set("method", tmpTarget.Method)
-	d.Set("recipient", tmpTarget.Recipient)
-	d.Set("email_subject", tmpTarget.EmailSubject)
-	d.Set("content_type", tmpTarget.ContentType)
-	d.Set("is_html_content", tmpTarget.IsHtmlContent)
-	d.Set("custom_headers", tmpTarget.CustomHeaders)
-	d.Set("target_id", fmt.Sprintf("target:%s", *tmpTarget.ID))
-
-	resourceEncodeAlertRoutes(&tmpTarget.Routes, d)
+	for key, value := range flattenTarget(&tmpTarget) {
+		d.Set(key, value)
+	}
 
 	return nil
 }
 
-func resourceTargetUpdate(d *schema.ResourceData, meta interface{}) error {
-	targets := meta.(*wavefrontClient).client.Targets()
+// flattenTarget returns the attributes of the alert target, shared by the
+// resource and the data sources.
+func flattenTarget(t *wavefront.Target) map[string]interface{} {
+	tfMap := map[string]interface{}{
+		"name":            t.Title,
+		descriptionKey:    t.Description,
+		"triggers":        t.Triggers,
+		"template":        t.Template,
+		"method":          t.Method,
+		"recipient":       t.Recipient,
+		"email_subject":   t.EmailSubject,
+		"content_type":    t.ContentType,
+		"is_html_content": t.IsHtmlContent,
+		"custom_headers":  t.CustomHeaders,
+		"target_id":       fmt.Sprintf("target:%s", *t.ID),
+	}
+	if t.Routes != nil {
+		tfMap["route"] = flattenAlertRoutes(t.Routes)
+	}
+	return tfMap
+}
+
+func resourceTargetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targets := meta.(*wavefrontClient).withContext(ctx).Targets()
 
 	results, err := targets.Find(
 		[]*wavefront.SearchCondition{
@@ -180,11 +195,11 @@ func resourceTargetUpdate(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront Alert Target %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Alert Target %s. %s", d.Id(), err)
 	}
 
 	if len(results) == 0 {
-		return fmt.Errorf("error finding Wavefront Alert Target %s", d.Id())
+		return diag.Errorf("error finding Wavefront Alert Target %s", d.Id())
 	}
 
 	var triggers []string
@@ -213,14 +228,14 @@ func resourceTargetUpdate(d *schema.ResourceData, meta interface{}) error {
 	// Update the Target on Wavefront
 	err = targets.Update(t)
 	if err != nil {
-		return fmt.Errorf("error Updating Target %s. %s", d.Get("name"), err)
+		return diag.Errorf("error Updating Target %s. %s", d.Get("name"), err)
 	}
 
-	return resourceTargetRead(d, meta)
+	return resourceTargetRead(ctx, d, meta)
 }
 
-func resourceTargetDelete(d *schema.ResourceData, meta interface{}) error {
-	targets := meta.(*wavefrontClient).client.Targets()
+func resourceTargetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targets := meta.(*wavefrontClient).withContext(ctx).Targets()
 
 	results, err := targets.Find(
 		[]*wavefront.SearchCondition{
@@ -231,14 +246,14 @@ func resourceTargetDelete(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront Target %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Target %s. %s", d.Id(), err)
 	}
 	t := results[0]
 
 	// Delete the Target
 	err = targets.Delete(t)
 	if err != nil {
-		return fmt.Errorf("failed to delete Target %s. %s", d.Id(), err)
+		return diag.Errorf("failed to delete Target %s. %s", d.Id(), err)
 	}
 	d.SetId("")
 	return nil
@@ -284,21 +299,19 @@ func resourceDecodeAlertRoutes(d *schema.ResourceData) []wavefront.AlertRoute {
 }
 
 // Convert the routes from AlertRoute -> Terraform Friendly
-func resourceEncodeAlertRoutes(routes *[]wavefront.AlertRoute, d *schema.ResourceData) {
-	if routes != nil {
-		var r []interface{}
-		for _, route := range *routes {
-			alertRoute := make(map[string]interface{})
-			filterKV := strings.Split(route.Filter, " ")
-			alertRoute["method"] = route.Method
-			alertRoute["target"] = route.Target
-			alertRoute["filter"] = map[string]interface{}{
-				"key":   filterKV[0],
-				"value": filterKV[1],
-			}
-
-			r = append(r, alertRoute)
+func flattenAlertRoutes(routes []wavefront.AlertRoute) []interface{} {
+	var r []interface{}
+	for _, route := range routes {
+		alertRoute := make(map[string]interface{})
+		filterKey, filterValue, _ := strings.Cut(route.Filter, " ")
+		alertRoute["method"] = route.Method
+		alertRoute["target"] = route.Target
+		alertRoute["filter"] = map[string]interface{}{
+			"key":   filterKey,
+			"value": filterValue,
 		}
-		d.Set("route", r)
+
+		r = append(r, alertRoute)
 	}
+	return r
 }
@@ -1,17 +1,91 @@
 package wavefront
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-type DecodeCloudIntegration func(*schema.ResourceData, *wavefront.CloudIntegration) error
-type EncodeCloudIntegration func(*schema.ResourceData, *wavefront.CloudIntegration) error
+type DecodeCloudIntegration func(*schema.ResourceData, *cloudIntegration) error
+type EncodeCloudIntegration func(*schema.ResourceData, *cloudIntegration) error
 
-func decodeAwsIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+// cloudIntegration is a cloud integration as the API serves it, with the
+// fields wavefront.CloudIntegration lacks.
+type cloudIntegration struct {
+	wavefront.CloudIntegration
+
+	// LastError is a digest of the last error encountered fetching data.
+	LastError string `json:"lastError,omitempty"`
+
+	Dynatrace *dynatraceConfiguration `json:"dynatrace,omitempty"`
+	Snowflake *snowflakeConfiguration `json:"snowflake,omitempty"`
+	Vrops     *vropsConfiguration     `json:"vrops,omitempty"`
+}
+
+type dynatraceConfiguration struct {
+	// The Dynatrace API token, never returned by the API
+	DynatraceAPIToken string `json:"dynatraceAPIToken"`
+
+	// The ID of the Dynatrace environment
+	EnvironmentID string `json:"environmentID"`
+
+	// A regular expression that a metric name must match in order to be ingested
+	MetricFilterRegex string `json:"metricFilterRegex,omitempty"`
+}
+
+type snowflakeConfiguration struct {
+	// The Snowflake account identifier
+	AccountID string `json:"accountID"`
+
+	// The user to authenticate as
+	UserName string `json:"userName"`
+
+	// The password of the user, never returned by the API
+	Password string `json:"password,omitempty"`
+
+	// The private key of the user, never returned by the API
+	PrivateKey string `json:"privateKey,omitempty"`
+
+	// The role of the user to query the metrics with
+	Role string `json:"role,omitempty"`
+
+	// The warehouse to query the metrics with
+	Warehouse string `json:"warehouse,omitempty"`
+
+	// A regular expression that a metric name must match in order to be ingested
+	MetricFilterRegex string `json:"metricFilterRegex,omitempty"`
+}
+
+type vropsConfiguration struct {
+	// The vRealize Operations instances to fetch metrics from
+	VropsAPIRequest []*vropsAPIRequest `json:"vropsAPIRequest"`
+
+	// The categories of metrics to fetch
+	CategoriesToFetch []string `json:"categoriesToFetch,omitempty"`
+
+	// A regular expression that a metric name must match in order to be ingested
+	MetricFilterRegex string `json:"metricFilterRegex,omitempty"`
+}
+
+type vropsAPIRequest struct {
+	// The URL of the vRealize Operations instance
+	BaseURL string `json:"baseURL"`
+
+	// The user to authenticate as
+	UserName string `json:"userName"`
+
+	// The password of the user, never returned by the API
+	Password string `json:"password"`
+}
+
+func decodeAwsIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	baseCredentials := &wavefront.AWSBaseCredentials{
 		RoleARN:    d.Get("role_arn").(string),
 		ExternalID: d.Get("external_id").(string),
@@ -52,7 +126,7 @@ func decodeAwsIntegration(d *schema.ResourceData, integration *wavefront.CloudIn
 	return nil
 }
 
-func decodeGcpIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func decodeGcpIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	jsonKey := d.Get("json_key").(string)
 	projectID := d.Get("project_id").(string)
 	switch integration.Service {
@@ -81,7 +155,7 @@ func decodeGcpIntegration(d *schema.ResourceData, integration *wavefront.CloudIn
 	return nil
 }
 
-func decodeNewRelicConfiguration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func decodeNewRelicConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
 	if integration.Service != "NEWRELIC" {
 		return fmt.Errorf("invalid service, expected NEWRELIC. got %s", integration.Service)
 	}
@@ -106,7 +180,7 @@ func decodeNewRelicConfiguration(d *schema.ResourceData, integration *wavefront.
 	return nil
 }
 
-func decodeAppDynamicsConfiguration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func decodeAppDynamicsConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
 	if integration.Service != "APPDYNAMICS" {
 		return fmt.Errorf("invalid service, expected APPDYNAMICS. got %s", integration.Service)
 	}
@@ -134,7 +208,7 @@ func decodeAppDynamicsConfiguration(d *schema.ResourceData, integration *wavefro
 	return nil
 }
 
-func decodeAzureIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func decodeAzureIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	azureBaseCredentials := &wavefront.AzureBaseCredentials{
 		ClientID:     d.Get("client_id").(string),
 		ClientSecret: d.Get("client_secret").(string),
@@ -174,7 +248,7 @@ func decodeAzureIntegration(d *schema.ResourceData, integration *wavefront.Cloud
 	return nil
 }
 
-func encodeAwsIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func encodeAwsIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	switch integration.Service {
 	case "CLOUDWATCH":
 		d.Set("metric_filter_regex", integration.CloudWatch.MetricFilterRegex)
@@ -203,7 +277,7 @@ func encodeAwsIntegration(d *schema.ResourceData, integration *wavefront.CloudIn
 	return nil
 }
 
-func encodeGcpIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func encodeGcpIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	switch integration.Service {
 	case "GCP":
 		d.Set("project_id", integration.GCP.ProjectId)
@@ -218,7 +292,7 @@ func encodeGcpIntegration(d *schema.ResourceData, integration *wavefront.CloudIn
 	return nil
 }
 
-func encodeNewRelicConfiguration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func encodeNewRelicConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
 	if integration.Service != "NEWRELIC" {
 		return fmt.Errorf("invalid service, expected NEWRELIC. got %s", integration.Service)
 	}
@@ -238,7 +312,7 @@ func encodeNewRelicConfiguration(d *schema.ResourceData, integration *wavefront.
 	return nil
 }
 
-func encodeAppDynamicsConfiguration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func encodeAppDynamicsConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
 	if integration.Service != "APPDYNAMICS" {
 		return fmt.Errorf("invalid service, expected APPDYNAMICS. got %s", integration.Service)
 	}
@@ -257,7 +331,7 @@ func encodeAppDynamicsConfiguration(d *schema.ResourceData, integration *wavefro
 	return nil
 }
 
-func encodeAzureCloudIntegration(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+func encodeAzureCloudIntegration(d *schema.ResourceData, integration *cloudIntegration) error {
 	switch integration.Service {
 	case "AZURE":
 		d.Set("metric_filter_regex", integration.Azure.MetricFilterRegex)
@@ -276,7 +350,117 @@ func encodeAzureCloudIntegration(d *schema.ResourceData, integration *wavefront.
 	return nil
 }
 
-func decodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.ResourceData) error {
+func decodeDynatraceConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "DYNATRACE" {
+		return fmt.Errorf("invalid service, expected DYNATRACE. got %s", integration.Service)
+	}
+	integration.Dynatrace = &dynatraceConfiguration{
+		DynatraceAPIToken: d.Get("api_token").(string),
+		EnvironmentID:     d.Get("environment_id").(string),
+		MetricFilterRegex: d.Get("metric_filter_regex").(string),
+	}
+	return nil
+}
+
+func decodeSnowflakeConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "SNOWFLAKE" {
+		return fmt.Errorf("invalid service, expected SNOWFLAKE. got %s", integration.Service)
+	}
+	integration.Snowflake = &snowflakeConfiguration{
+		AccountID:         d.Get("account_id").(string),
+		UserName:          d.Get("user_name").(string),
+		Password:          d.Get("password").(string),
+		PrivateKey:        d.Get("private_key").(string),
+		Role:              d.Get("role").(string),
+		Warehouse:         d.Get("warehouse").(string),
+		MetricFilterRegex: d.Get("metric_filter_regex").(string),
+	}
+	return nil
+}
+
+func decodeVropsConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "VROPS" {
+		return fmt.Errorf("invalid service, expected VROPS. got %s", integration.Service)
+	}
+	var apiRequests []*vropsAPIRequest
+	for _, v := range d.Get("api_request").([]interface{}) {
+		apiRequest := v.(map[string]interface{})
+		apiRequests = append(apiRequests, &vropsAPIRequest{
+			BaseURL:  apiRequest["base_url"].(string),
+			UserName: apiRequest["user_name"].(string),
+			Password: apiRequest["password"].(string),
+		})
+	}
+	integration.Vrops = &vropsConfiguration{
+		VropsAPIRequest:   apiRequests,
+		CategoriesToFetch: decodeTypeListToString(d, "categories"),
+		MetricFilterRegex: d.Get("metric_filter_regex").(string),
+	}
+	return nil
+}
+
+func encodeDynatraceConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "DYNATRACE" {
+		return fmt.Errorf("invalid service, expected DYNATRACE. got %s", integration.Service)
+	}
+	if integration.Dynatrace == nil {
+		return fmt.Errorf("the DYNATRACE cloud integration %s has no configuration", integration.Id)
+	}
+	// The API never returns the API token, so it's kept from the state.
+	d.Set("environment_id", integration.Dynatrace.EnvironmentID)
+	d.Set("metric_filter_regex", integration.Dynatrace.MetricFilterRegex)
+	return nil
+}
+
+func encodeSnowflakeConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "SNOWFLAKE" {
+		return fmt.Errorf("invalid service, expected SNOWFLAKE. got %s", integration.Service)
+	}
+	if integration.Snowflake == nil {
+		return fmt.Errorf("the SNOWFLAKE cloud integration %s has no configuration", integration.Id)
+	}
+	// The API never returns the password or the private key, so they're kept
+	// from the state.
+	d.Set("account_id", integration.Snowflake.AccountID)
+	d.Set("user_name", integration.Snowflake.UserName)
+	d.Set("role", integration.Snowflake.Role)
+	d.Set("warehouse", integration.Snowflake.Warehouse)
+	d.Set("metric_filter_regex", integration.Snowflake.MetricFilterRegex)
+	return nil
+}
+
+func encodeVropsConfiguration(d *schema.ResourceData, integration *cloudIntegration) error {
+	if integration.Service != "VROPS" {
+		return fmt.Errorf("invalid service, expected VROPS. got %s", integration.Service)
+	}
+	if integration.Vrops == nil {
+		return fmt.Errorf("the VROPS cloud integration %s has no configuration", integration.Id)
+	}
+	// The API never returns the passwords, so each is kept from the state
+	// while the instance it authenticates to doesn't change.
+	previous := d.Get("api_request").([]interface{})
+	apiRequests := make([]map[string]interface{}, 0, len(integration.Vrops.VropsAPIRequest))
+	for i, v := range integration.Vrops.VropsAPIRequest {
+		password := ""
+		if i < len(previous) {
+			if p, ok := previous[i].(map[string]interface{}); ok &&
+				p["base_url"] == v.BaseURL && p["user_name"] == v.UserName {
+				password = p["password"].(string)
+			}
+		}
+		apiRequests = append(apiRequests, map[string]interface{}{
+			"base_url":  v.BaseURL,
+			"user_name": v.UserName,
+			"password":  password,
+		})
+	}
+	d.Set("api_request", apiRequests)
+	d.Set("categories", integration.Vrops.CategoriesToFetch)
+	d.Set("metric_filter_regex", integration.Vrops.MetricFilterRegex)
+	return nil
+}
+
+func decodeCloudIntegration(integration *cloudIntegration, d *schema.ResourceData) error {
 	b := map[string]DecodeCloudIntegration{
 		"CLOUDWATCH":  decodeAwsIntegration,
 		"CLOUDTRAIL":  decodeAwsIntegration,
@@ -285,7 +469,7 @@ func decodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.R
 		"GCPBILLING":  decodeGcpIntegration,
 		"NEWRELIC":    decodeNewRelicConfiguration,
 		"APPDYNAMICS": decodeAppDynamicsConfiguration,
-		"TESLA": func(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+		"TESLA": func(d *schema.ResourceData, integration *cloudIntegration) error {
 			integration.Tesla = &wavefront.TeslaConfiguration{
 				Email:    d.Get("email").(string),
 				Password: d.Get("password").(string),
@@ -294,6 +478,9 @@ func decodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.R
 		},
 		"AZURE":            decodeAzureIntegration,
 		"AZUREACTIVITYLOG": decodeAzureIntegration,
+		"DYNATRACE":        decodeDynatraceConfiguration,
+		"SNOWFLAKE":        decodeSnowflakeConfiguration,
+		"VROPS":            decodeVropsConfiguration,
 	}
 
 	service := d.Get("service").(string)
@@ -304,7 +491,7 @@ func decodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.R
 	return fmt.Errorf("invalid service \"%s\" specified", service)
 }
 
-func encodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.ResourceData) error {
+func encodeCloudIntegration(integration *cloudIntegration, d *schema.ResourceData) error {
 	b := map[string]EncodeCloudIntegration{
 		"CLOUDWATCH":  encodeAwsIntegration,
 		"CLOUDTRAIL":  encodeAwsIntegration,
@@ -313,13 +500,16 @@ func encodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.R
 		"GCPBILLING":  encodeGcpIntegration,
 		"NEWRELIC":    encodeNewRelicConfiguration,
 		"APPDYNAMICS": encodeAppDynamicsConfiguration,
-		"TESLA": func(d *schema.ResourceData, integration *wavefront.CloudIntegration) error {
+		"TESLA": func(d *schema.ResourceData, integration *cloudIntegration) error {
 			// The API will NEVER return a changed password this is problematic.
 			d.Set("email", integration.Tesla.Email)
 			return nil
 		},
 		"AZURE":            encodeAzureCloudIntegration,
 		"AZUREACTIVITYLOG": encodeAzureCloudIntegration,
+		"DYNATRACE":        encodeDynatraceConfiguration,
+		"SNOWFLAKE":        encodeSnowflakeConfiguration,
+		"VROPS":            encodeVropsConfiguration,
 	}
 
 	service := integration.Service
@@ -330,54 +520,107 @@ func encodeCloudIntegration(integration *wavefront.CloudIntegration, d *schema.R
 	return fmt.Errorf("invalid service \"%s\" specified", service)
 }
 
-func resourceCloudIntegrationCreate(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
+// cloudIntegrationPath is the path of the cloud integration API relative to
+// the base URL of the client, so that a path in the address is kept.
+const cloudIntegrationPath = "cloudintegration"
+
+// doCloudIntegrationRequest sends a request for cloud integrations with the
+// integration, when not nil, as the payload, decoding the integration in the
+// response into it. wavefront.CloudIntegrations sends and decodes
+// wavefront.CloudIntegration, which lacks the configuration of some
+// services, so cloud integrations are requested here.
+func doCloudIntegrationRequest(
+	client *wavefront.Client, method, path string, params *map[string]string, integration *cloudIntegration,
+) error {
+	var payload []byte
+	if integration != nil {
+		var err error
+		if payload, err = json.Marshal(integration); err != nil {
+			return err
+		}
+	}
+	req, err := client.NewRequest(method, path, params, payload)
+	if err != nil {
+		return err
+	}
+	body, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	if integration == nil {
+		return nil
+	}
+
+	resp := struct {
+		Response *cloudIntegration `json:"response"`
+	}{Response: integration}
+	return json.NewDecoder(body).Decode(&resp)
+}
+
+// findCloudIntegration returns the cloud integration with the given ID, or
+// nil when there's none.
+func findCloudIntegration(ctx context.Context, id string, meta interface{}) (*cloudIntegration, error) {
+	filter := []*searchCondition{{Key: "id", Value: id, MatchingMethod: "EXACT"}}
+	items, err := searchAll(ctx, 1, 0, "cloudintegration", nil, filter, meta)
+	if err != nil {
+		return nil, err
+	}
+	var integrations []*cloudIntegration
+	if err = json.Unmarshal(items, &integrations); err != nil {
+		return nil, err
+	}
+	if len(integrations) == 0 {
+		return nil, nil
+	}
+	return integrations[0], nil
+}
+
+func resourceCloudIntegrationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
 
 	pointTags := decodeTypeMapToStringMap(d, "additional_tags")
-	integration := &wavefront.CloudIntegration{
+	integration := &cloudIntegration{CloudIntegration: wavefront.CloudIntegration{
 		Name:                     d.Get("name").(string),
 		ForceSave:                d.Get("force_save").(bool),
 		Service:                  d.Get("service").(string),
 		ServiceRefreshRateInMins: d.Get("service_refresh_rate_in_minutes").(int),
 		AdditionalTags:           pointTags,
-	}
+	}}
 
 	// configure the integration based on the service
 	err := decodeCloudIntegration(integration, d)
 	if err != nil {
-		return fmt.Errorf("error binding state to wavefront.CloudIntegration. %s", err)
+		return diag.Errorf("error binding state to wavefront.CloudIntegration. %s", err)
 	}
 
 	wfMutexKV.Lock("cloud_integration_create")
-	err = cloudIntegrations.Create(integration)
+	err = doCloudIntegrationRequest(client, http.MethodPost, cloudIntegrationPath, nil, integration)
 	wfMutexKV.Unlock("cloud_integration_create")
 
 	if err != nil {
-		return fmt.Errorf("error creating Cloud Integration for service %s. got %s", d.Get("service"), err)
+		return diag.Errorf("error creating Cloud Integration for service %s. got %s", d.Get("service"), err)
 	}
 
 	d.SetId(integration.Id)
-	return resourceCloudIntegrationRead(d, meta)
+	return resourceCloudIntegrationRead(ctx, d, meta)
 }
 
-func resourceCloudIntegrationUpdate(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
-	integrations, err := cloudIntegrations.Find([]*wavefront.SearchCondition{
-		{
-			Key:            "id",
-			Value:          d.Id(),
-			MatchingMethod: "EXACT",
-		},
-	})
+func resourceCloudIntegrationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	integration, err := findCloudIntegration(ctx, d.Id(), meta)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
+	}
+	if integration == nil {
+		d.SetId("")
+		return nil
 	}
 
-	integration := integrations[0]
 	integration.Name = d.Get("name").(string)
 	integration.ForceSave = d.Get("force_save").(bool)
 	integration.Service = d.Get("service").(string)
@@ -392,42 +635,35 @@ func resourceCloudIntegrationUpdate(d *schema.ResourceData, meta interface{}) er
 	// configure the integration based on the service
 	err = decodeCloudIntegration(integration, d)
 	if err != nil {
-		return fmt.Errorf("error binding state to wavefront.CloudIntegration. %s", err)
+		return diag.Errorf("error binding state to wavefront.CloudIntegration. %s", err)
 	}
 
 	wfMutexKV.Lock("cloud_integration_update")
-	err = cloudIntegrations.Update(integration)
+	err = doCloudIntegrationRequest(
+		client, http.MethodPut, cloudIntegrationPath+"/"+url.PathEscape(d.Id()), nil, integration)
 	wfMutexKV.Unlock("cloud_integration_update")
 
 	if err != nil {
-		return fmt.Errorf("unable to update CloudIntegration with id %s. %s", d.Id(), err)
+		return diag.Errorf("unable to update CloudIntegration with id %s. %s", d.Id(), err)
 	}
 
-	return resourceCloudIntegrationRead(d, meta)
+	return resourceCloudIntegrationRead(ctx, d, meta)
 }
 
-func resourceCloudIntegrationRead(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
-	integrations, err := cloudIntegrations.Find([]*wavefront.SearchCondition{
-		{
-			Key:            "id",
-			Value:          d.Id(),
-			MatchingMethod: "EXACT",
-		},
-	})
+func resourceCloudIntegrationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	integration, err := findCloudIntegration(ctx, d.Id(), meta)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
 	}
 
-	if len(integrations) <= 0 {
+	if integration == nil {
 		d.SetId("")
 		return nil
 	}
-	integration := integrations[0]
 	/*
 		You will notice we don't ever set the forceSave and that is because it is ALWAYS returned as false
 		We'll use it when we create/update because it is necessary, but we won't set the state on read from it
@@ -438,30 +674,29 @@ func resourceCloudIntegrationRead(d *schema.ResourceData, meta interface{}) erro
 	d.Set("additional_tags", integration.AdditionalTags)
 	d.Set("service_refresh_rate_in_minutes", integration.ServiceRefreshRateInMins)
 
-	return encodeCloudIntegration(integration, d)
+	return diag.FromErr(encodeCloudIntegration(integration, d))
 }
 
-func resourceCloudIntegrationDelete(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
-	integrations, err := cloudIntegrations.Find([]*wavefront.SearchCondition{
-		{
-			Key:            "id",
-			Value:          d.Id(),
-			MatchingMethod: "EXACT",
-		},
-	})
+func resourceCloudIntegrationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	integration, err := findCloudIntegration(ctx, d.Id(), meta)
 	if err != nil {
 		if strings.Contains(err.Error(), "404") {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find CloudIntegration with ID %s. %s", d.Id(), err)
+	}
+	if integration == nil {
+		d.SetId("")
+		return nil
 	}
 
-	integration := integrations[0]
-	err = cloudIntegrations.Delete(integration, true)
+	params := map[string]string{"skipTrash": "true"}
+	err = doCloudIntegrationRequest(
+		client, http.MethodDelete, cloudIntegrationPath+"/"+url.PathEscape(integration.Id), &params, nil)
 	if err != nil {
-		return fmt.Errorf("error deleting Cloud Integration. %s", err)
+		return diag.Errorf("error deleting Cloud Integration. %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -1,38 +1,46 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCloudIntegrationAwsExternalID() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudIntegrationAwsExternalIDCreate,
-		Read:   resourceCloudIntegrationAwsExternalIDRead,
-		Delete: resourceCloudIntegrationAwsExternalIDDelete,
+		CreateContext: resourceCloudIntegrationAwsExternalIDCreate,
+		ReadContext:   resourceCloudIntegrationAwsExternalIDRead,
+		DeleteContext: resourceCloudIntegrationAwsExternalIDDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: map[string]*schema.Schema{},
+		Timeouts: resourceTimeouts(),
+		// The SDK can't add the timeouts block to an empty schema.
+		Schema: map[string]*schema.Schema{
+			"external_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
 	}
 }
 
-func resourceCloudIntegrationAwsExternalIDCreate(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
+func resourceCloudIntegrationAwsExternalIDCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudIntegrations := meta.(*wavefrontClient).withContext(ctx).CloudIntegrations()
 
 	extID, err := cloudIntegrations.CreateAwsExternalID()
 	if err != nil {
-		return fmt.Errorf("error creating AWS External ID. %s", err)
+		return diag.Errorf("error creating AWS External ID. %s", err)
 	}
 
 	d.SetId(extID)
-	return nil
+	return diag.FromErr(d.Set("external_id", extID))
 }
 
-func resourceCloudIntegrationAwsExternalIDRead(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
+func resourceCloudIntegrationAwsExternalIDRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudIntegrations := meta.(*wavefrontClient).withContext(ctx).CloudIntegrations()
 	extID := d.Id()
 	err := cloudIntegrations.VerifyAwsExternalID(extID)
 	if err != nil {
@@ -40,19 +48,19 @@ func resourceCloudIntegrationAwsExternalIDRead(d *schema.ResourceData, meta inte
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find AWS External ID %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find AWS External ID %s. %s", d.Id(), err)
 	}
 	d.SetId(extID)
 
-	return nil
+	return diag.FromErr(d.Set("external_id", extID))
 }
 
-func resourceCloudIntegrationAwsExternalIDDelete(d *schema.ResourceData, meta interface{}) error {
-	cloudIntegrations := meta.(*wavefrontClient).client.CloudIntegrations()
+func resourceCloudIntegrationAwsExternalIDDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	cloudIntegrations := meta.(*wavefrontClient).withContext(ctx).CloudIntegrations()
 	extID := d.Id()
 	err := cloudIntegrations.DeleteAwsExternalID(&extID)
 	if err != nil {
-		return fmt.Errorf("error deleting AWS External ID. %s", err)
+		return diag.Errorf("error deleting AWS External ID. %s", err)
 	}
 	d.SetId("")
 	return nil
@@ -19,6 +19,9 @@ func TestAccWavefrontCloudIntegrationAwsExternalId_Basic(t *testing.T) {
 				Config: testAccCheckWavefrontCloudIntegrationAwsExternalIDBasic(),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckWavefrontCloudIntegrationAwsExternalIDExists(),
+					resource.TestCheckResourceAttrPair(
+						"wavefront_cloud_integration_aws_external_id.external_id", "external_id",
+						"wavefront_cloud_integration_aws_external_id.external_id", "id"),
 				),
 			},
 		},
@@ -6,13 +6,14 @@ import (
 
 func resourceCloudIntegrationAzure() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCloudIntegrationCreate,
-		Read:   resourceCloudIntegrationRead,
-		Update: resourceCloudIntegrationUpdate,
-		Delete: resourceCloudIntegrationDelete,
+		CreateContext: resourceCloudIntegrationCreate,
+		ReadContext:   resourceCloudIntegrationRead,
+		UpdateContext: resourceCloudIntegrationUpdate,
+		DeleteContext: resourceCloudIntegrationDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -0,0 +1,50 @@
+package wavefront
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func resourceCloudIntegrationDynatrace() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCloudIntegrationCreate,
+		ReadContext:   resourceCloudIntegrationRead,
+		UpdateContext: resourceCloudIntegrationUpdate,
+		DeleteContext: resourceCloudIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"additional_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force_save": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"service": serviceSchemaDefinition(wfDynatrace),
+			"service_refresh_rate_in_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+			"api_token": {
+				Sensitive: true,
+				Type:      schema.TypeString,
+				Required:  true,
+			},
+			"environment_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"metric_filter_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
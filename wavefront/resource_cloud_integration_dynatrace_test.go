@@ -0,0 +1,73 @@
+package wavefront
+
+import (
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWavefrontCloudIntegrationDynatrace_BasicChanged(t *testing.T) {
+	var record wavefront.CloudIntegration
+	resourcePrefix := "wavefront_cloud_integration_dynatrace.dynatrace"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontCloudIntegrationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontCloudIntegrationDynatraceBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontCloudIntegrationExists(resourcePrefix, &record),
+					testAccCheckWavefrontCloudIntegrationAttributes(&record, wfDynatrace),
+					// Check against state that the attributes are as we expect
+					testAccCheckWavefrontCloudIntegrationResourceAttributes(resourcePrefix, wfDynatrace),
+					resource.TestCheckResourceAttr(resourcePrefix, "environment_id", "abc12345"),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_token", "example-api-token"),
+					resource.TestCheckResourceAttr(resourcePrefix, "metric_filter_regex", ""),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontCloudIntegrationDynatraceBasicChanged(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontCloudIntegrationExists(resourcePrefix, &record),
+					testAccCheckWavefrontCloudIntegrationAttributes(&record, wfDynatrace),
+					// Check against state that the attributes are as we expect
+					testAccCheckWavefrontCloudIntegrationResourceAttributes(resourcePrefix, wfDynatrace),
+					resource.TestCheckResourceAttr(resourcePrefix, "environment_id", "abc12345"),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_token", "example-api-token2"),
+					resource.TestCheckResourceAttr(resourcePrefix, "metric_filter_regex", "^builtin:host\\..*$"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontCloudIntegrationDynatraceBasic() string {
+	return `
+resource "wavefront_cloud_integration_dynatrace" "dynatrace" {
+  name       = "Test Integration"
+  force_save = true
+  additional_tags = {
+    "tag1" = "value1"
+    "tag2" = "value2"
+  }
+  environment_id = "abc12345"
+  api_token      = "example-api-token"
+}`
+}
+
+func testAccCheckWavefrontCloudIntegrationDynatraceBasicChanged() string {
+	return `
+resource "wavefront_cloud_integration_dynatrace" "dynatrace" {
+  name       = "Test Integration"
+  force_save = true
+  additional_tags = {
+    "tag1" = "value1"
+    "tag2" = "value2"
+  }
+  environment_id      = "abc12345"
+  api_token           = "example-api-token2"
+  metric_filter_regex = "^builtin:host\\..*$"
+}`
+}
@@ -0,0 +1,70 @@
+package wavefront
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func resourceCloudIntegrationSnowflake() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCloudIntegrationCreate,
+		ReadContext:   resourceCloudIntegrationRead,
+		UpdateContext: resourceCloudIntegrationUpdate,
+		DeleteContext: resourceCloudIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"additional_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force_save": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"service": serviceSchemaDefinition(wfSnowflake),
+			"service_refresh_rate_in_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"user_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"password": {
+				Sensitive:    true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"password", "private_key"},
+			},
+			"private_key": {
+				Sensitive:    true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				StateFunc:    trimSpaces,
+				ExactlyOneOf: []string{"password", "private_key"},
+			},
+			"role": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"warehouse": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"metric_filter_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
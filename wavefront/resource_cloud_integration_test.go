@@ -2,13 +2,34 @@ package wavefront
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"strings"
+	"testing"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func TestDoCloudIntegrationRequest_KeepsAddressPath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"status":{"code":200},"response":{"id":"1234","service":"VROPS"}}`)
+	}))
+	defer server.Close()
+
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL + "/wavefront", Token: "secret"})
+	require.NoError(t, err)
+	integration := &cloudIntegration{}
+	require.NoError(t, doCloudIntegrationRequest(client, http.MethodGet, cloudIntegrationPath+"/1234", nil, integration))
+	assert.Equal(t, []string{"/wavefront/api/v2/cloudintegration/1234"}, paths)
+	assert.Equal(t, "1234", integration.Id)
+}
+
 func testAccCheckWavefrontCloudIntegrationDestroy(s *terraform.State) error {
 	integrations := testAccProvider.Meta().(*wavefrontClient).client.CloudIntegrations()
 	for _, rs := range s.RootModule().Resources {
@@ -0,0 +1,69 @@
+package wavefront
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+func resourceCloudIntegrationVrops() *schema.Resource {
+	vropsAPIRequests := &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MinItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"base_url": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"user_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"password": {
+					Sensitive: true,
+					Type:      schema.TypeString,
+					Required:  true,
+				},
+			},
+		},
+	}
+	return &schema.Resource{
+		CreateContext: resourceCloudIntegrationCreate,
+		ReadContext:   resourceCloudIntegrationRead,
+		UpdateContext: resourceCloudIntegrationUpdate,
+		DeleteContext: resourceCloudIntegrationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"additional_tags": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"force_save": {
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+			"service": serviceSchemaDefinition(wfVrops),
+			"service_refresh_rate_in_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  5,
+			},
+			"api_request": vropsAPIRequests,
+			"categories": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"metric_filter_regex": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package wavefront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudIntegrationVrops_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	ctx := context.Background()
+
+	vrops := resourceCloudIntegrationVrops()
+	d := schema.TestResourceDataRaw(t, vrops.Schema, map[string]interface{}{
+		"name": "vROps Integration",
+		"api_request": []interface{}{
+			map[string]interface{}{"base_url": "https://vrops-1.example.com", "user_name": "admin", "password": "secret-1"},
+			map[string]interface{}{"base_url": "https://vrops-2.example.com", "user_name": "admin", "password": "secret-2"},
+		},
+		"categories": []interface{}{"VIRTUALMACHINE", "HOSTSYSTEM"},
+	})
+	diags := vrops.CreateContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NotEmpty(t, d.Id())
+
+	integration, err := findCloudIntegration(ctx, d.Id(), provider.Meta())
+	require.NoError(t, err)
+	require.NotNil(t, integration.Vrops, "the configuration of the service must be sent")
+	assert.Equal(t, wfVrops, integration.Service)
+	assert.Equal(t, "secret-2", integration.Vrops.VropsAPIRequest[1].Password)
+	assert.Equal(t, []string{"VIRTUALMACHINE", "HOSTSYSTEM"}, integration.Vrops.CategoriesToFetch)
+
+	// The API never returns the passwords, which are kept from the state
+	integration.Vrops.VropsAPIRequest[0].Password = ""
+	integration.Vrops.VropsAPIRequest[1].Password = ""
+	integration.Vrops.VropsAPIRequest[1].BaseURL = "https://vrops-3.example.com"
+	require.NoError(t, encodeCloudIntegration(integration, d))
+	assert.Equal(t, "secret-1", d.Get("api_request.0.password"))
+	assert.Equal(t, "", d.Get("api_request.1.password"), "the password of another instance must not be kept")
+
+	integration.Vrops = nil
+	assert.ErrorContains(t, encodeCloudIntegration(integration, d), "has no configuration")
+
+	imported := schema.TestResourceDataRaw(t, vrops.Schema, map[string]interface{}{})
+	imported.SetId(d.Id())
+	diags = vrops.ReadContext(ctx, imported, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "vROps Integration", imported.Get("name"))
+	assert.Equal(t, "https://vrops-2.example.com", imported.Get("api_request.1.base_url"))
+	assert.Equal(t, "", imported.Get("api_request.1.password"))
+
+	cloudIntegration := dataSourceCloudIntegration()
+	ds := schema.TestResourceDataRaw(t, cloudIntegration.Schema, map[string]interface{}{idKey: d.Id()})
+	diags = cloudIntegration.ReadContext(ctx, ds, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "https://vrops-1.example.com", ds.Get("vrops.0.api_request.0.base_url"))
+	for key, value := range ds.State().Attributes {
+		assert.NotContains(t, value, "secret-", "the password must not be in %s", key)
+	}
+
+	diags = vrops.DeleteContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	integration, err = findCloudIntegration(ctx, imported.Id(), provider.Meta())
+	require.NoError(t, err)
+	assert.Nil(t, integration)
+}
+
+func TestAccWavefrontCloudIntegrationVrops_BasicChanged(t *testing.T) {
+	var record wavefront.CloudIntegration
+	resourcePrefix := "wavefront_cloud_integration_vrops.vrops"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontCloudIntegrationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontCloudIntegrationVropsBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontCloudIntegrationExists(resourcePrefix, &record),
+					testAccCheckWavefrontCloudIntegrationAttributes(&record, wfVrops),
+					// Check against state that the attributes are as we expect
+					testAccCheckWavefrontCloudIntegrationResourceAttributes(resourcePrefix, wfVrops),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_request.#", "1"),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_request.0.base_url", "https://vrops.example.com"),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_request.0.password", "example-password"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontCloudIntegrationVropsBasicChanged(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontCloudIntegrationExists(resourcePrefix, &record),
+					testAccCheckWavefrontCloudIntegrationAttributes(&record, wfVrops),
+					// Check against state that the attributes are as we expect
+					testAccCheckWavefrontCloudIntegrationResourceAttributes(resourcePrefix, wfVrops),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_request.#", "2"),
+					resource.TestCheckResourceAttr(resourcePrefix, "api_request.1.password", "example-password2"),
+					resource.TestCheckResourceAttr(resourcePrefix, "categories.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontCloudIntegrationVropsBasic() string {
+	return `
+resource "wavefront_cloud_integration_vrops" "vrops" {
+  name       = "Test Integration"
+  force_save = true
+  additional_tags = {
+    "tag1" = "value1"
+    "tag2" = "value2"
+  }
+  api_request {
+    base_url  = "https://vrops.example.com"
+    user_name = "admin"
+    password  = "example-password"
+  }
+}`
+}
+
+func testAccCheckWavefrontCloudIntegrationVropsBasicChanged() string {
+	return `
+resource "wavefront_cloud_integration_vrops" "vrops" {
+  name       = "Test Integration"
+  force_save = true
+  additional_tags = {
+    "tag1" = "value1"
+    "tag2" = "value2"
+  }
+  api_request {
+    base_url  = "https://vrops.example.com"
+    user_name = "admin"
+    password  = "example-password"
+  }
+  api_request {
+    base_url  = "https://vrops2.example.com"
+    user_name = "admin"
+    password  = "example-password2"
+  }
+  categories = ["VIRTUALMACHINE"]
+}`
+}
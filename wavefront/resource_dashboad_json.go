@@ -1,23 +1,31 @@
 package wavefront
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDashboardJSON() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDashboardJSONCreate,
-		Read:   resourceDashboardJSONRead,
-		Update: resourceDashboardJSONUpdate,
-		Delete: resourceDashboardJSONDelete,
+		CreateContext: resourceDashboardJSONCreate,
+		ReadContext:   resourceDashboardJSONRead,
+		UpdateContext: resourceDashboardJSONUpdate,
+		DeleteContext: resourceDashboardJSONDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"dashboard_json": {
@@ -42,8 +50,8 @@ func buildDashboardJSON(d *schema.ResourceData) (*wavefront.Dashboard, error) {
 	return &dashboard, nil
 }
 
-func resourceDashboardJSONRead(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+func resourceDashboardJSONRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dash := wavefront.Dashboard{
 		ID: d.Id(),
 	}
@@ -53,75 +61,91 @@ func resourceDashboardJSONRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+	}
+	// The access control list is only managed when dashboard_json sets one,
+	// so keep it out of the state otherwise, unless the dashboard is being
+	// imported.
+	if previous := d.Get("dashboard_json").(string); previous != "" {
+		var configured wavefront.Dashboard
+		if parseErr := configured.UnmarshalJSON([]byte(previous)); parseErr == nil && isEmptyACL(configured.ACL) {
+			dash.ACL = wavefront.AccessControlList{}
+		}
 	}
 	bytes, _ := dash.MarshalJSON()
 	// Use the Wavefront url as the Terraform ID
 	d.SetId(dash.ID)
 	err = d.Set("dashboard_json", NormalizeDashboardJSON(string(bytes)))
 	if err != nil {
-		return fmt.Errorf("failed to set dashboard json %s. %s", d.Id(), err)
+		return diag.Errorf("failed to set dashboard json %s. %s", d.Id(), err)
 	}
 	return nil
 }
 
-func resourceDashboardJSONCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceDashboardJSONCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Create Wavefront Dashboard %s", d.Id())
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dashboard, err := buildDashboardJSON(d)
 
 	if err != nil {
-		return fmt.Errorf("failed to parse dashboard, %s", err)
+		return diag.Errorf("failed to parse dashboard, %s", err)
 	}
 
-	canView := dashboard.ACL.CanView
-	canModify := dashboard.ACL.CanModify
+	acl := dashboard.ACL
 
 	err = dashboards.Create(dashboard)
 	if err != nil {
-		return fmt.Errorf("failed to create dashboard, %s", err)
+		return diag.Errorf("failed to create dashboard, %s", err)
 	}
 
 	d.SetId(dashboard.ID)
 	log.Printf("[INFO] Wavefront Dashboard %s Created", d.Id())
 
-	err = dashboards.SetACL(dashboard.ID, canView, canModify)
-	if err != nil {
-		return fmt.Errorf("error setting ACL on Dashboard %s. %s", dashboard.Name, err)
+	if !isEmptyACL(acl) {
+		err = dashboards.SetACL(dashboard.ID, acl.CanView, acl.CanModify)
+		if err != nil {
+			return diag.Errorf("error setting ACL on Dashboard %s. %s", dashboard.Name, err)
+		}
 	}
 
-	return resourceDashboardJSONRead(d, meta)
+	return resourceDashboardJSONRead(ctx, d, meta)
 }
 
-func resourceDashboardJSONUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceDashboardJSONUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[INFO] Update Wavefront Dashboard %s", d.Id())
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dashboard, err := buildDashboardJSON(d)
 
 	if err != nil {
-		return fmt.Errorf("failed to parse dashboard, %s", err)
+		return diag.Errorf("failed to parse dashboard, %s", err)
 	}
 
-	canView := dashboard.ACL.CanView
-	canModify := dashboard.ACL.CanModify
+	acl := dashboard.ACL
 
 	err = dashboards.Update(dashboard)
 	if err != nil {
-		return fmt.Errorf("failed to create dashboard, %s", err)
+		return diag.Errorf("failed to create dashboard, %s", err)
 	}
 
 	log.Printf("[INFO] Wavefront Dashboard %s Updated", d.Id())
 
-	err = dashboards.SetACL(dashboard.ID, canView, canModify)
-	if err != nil {
-		return fmt.Errorf("error setting ACL on Dashboard %s. %s", dashboard.Name, err)
+	// The access control list is only set when it changes, so that it can be
+	// managed by wavefront_dashboard_acl when dashboard_json has none.
+	var previous wavefront.Dashboard
+	previousJSON, _ := d.GetChange("dashboard_json")
+	_ = previous.UnmarshalJSON([]byte(previousJSON.(string)))
+	if !(isEmptyACL(previous.ACL) && isEmptyACL(acl)) && !reflect.DeepEqual(previous.ACL, acl) {
+		err = dashboards.SetACL(dashboard.ID, acl.CanView, acl.CanModify)
+		if err != nil {
+			return diag.Errorf("error setting ACL on Dashboard %s. %s", dashboard.Name, err)
+		}
 	}
 
-	return resourceDashboardJSONRead(d, meta)
+	return resourceDashboardJSONRead(ctx, d, meta)
 }
 
-func resourceDashboardJSONDelete(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+func resourceDashboardJSONDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dash := wavefront.Dashboard{
 		ID: d.Id(),
 	}
@@ -133,13 +157,13 @@ func resourceDashboardJSONDelete(d *schema.ResourceData, meta interface{}) error
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
 	}
 
 	// Delete the Dashboard
 	err = dashboards.Delete(&dash, true)
 	if err != nil {
-		return fmt.Errorf("failed to delete Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("failed to delete Dashboard %s. %s", d.Id(), err)
 	}
 	d.SetId("")
 	return nil
@@ -155,6 +179,12 @@ func ValidateDashboardJSON(val interface{}, _ string) ([]string, []error) {
 	return nil, nil
 }
 
+// NormalizeDashboardJSON normalizes dashboard JSON so that it only differs
+// from the JSON of the dashboard in Wavefront on meaningful changes. The
+// fields managed by Wavefront are removed, and so are the fields set to their
+// zero values or to the defaults Wavefront sets. Tags and access control
+// lists are sorted, and so are the keys of objects, so the JSON doesn't
+// change when Wavefront serializes the dashboard differently.
 func NormalizeDashboardJSON(val interface{}) string {
 	dashboardJSONString := val.(string)
 	var dashboard wavefront.Dashboard
@@ -176,6 +206,188 @@ func NormalizeDashboardJSON(val interface{}) string {
 	dashboard.NumFavorites = 0
 	dashboard.Favorite = false
 
+	// Wavefront doesn't keep the order of tags and access control lists
+	sort.Strings(dashboard.Tags)
+	sort.Strings(dashboard.ACL.CanView)
+	sort.Strings(dashboard.ACL.CanModify)
+
 	ret, _ := dashboard.MarshalJSON()
-	return string(ret)
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(ret))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return string(ret)
+	}
+	canonical, err := marshalCanonicalJSON(pruneDashboardJSON(value, ""))
+	if err != nil {
+		return string(ret)
+	}
+	return canonical
+}
+
+// dashboardDefaults are the values Wavefront sets on dashboards when they're
+// missing, keyed by their path without the indexes of lists.
+var dashboardDefaults = map[string]string{
+	"eventFilterType":                                        "BYCHART",
+	"sections[].rows[].heightFactor":                         "50",
+	"sections[].rows[].charts[].summarization":               "MEAN",
+	"sections[].rows[].charts[].chartSettings.type":          "line",
+	"sections[].rows[].charts[].sources[].scatterPlotSource": "Y",
+}
+
+// pruneDashboardJSON removes the fields of the decoded dashboard JSON value at
+// path which are set to their zero values or to their defaults. Chart
+// attributes are free-form, so they're kept as they are.
+func pruneDashboardJSON(value interface{}, path string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if key != "chartAttributes" {
+				child = pruneDashboardJSON(child, childPath)
+				v[key] = child
+			}
+			if isZeroJSON(child) || dashboardDefaults[childPath] == fmt.Sprint(child) {
+				delete(v, key)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = pruneDashboardJSON(child, path+"[]")
+		}
+	}
+	return value
+}
+
+// isZeroJSON returns whether the decoded JSON value is null, false, zero, or
+// an empty string, list or object.
+func isZeroJSON(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case json.Number:
+		f, err := v.Float64()
+		return err == nil && f == 0
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// marshalCanonicalJSON encodes the decoded JSON value with the keys of its
+// objects sorted and without escaping HTML characters, which are common in
+// queries.
+func marshalCanonicalJSON(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// maxDashboardJSONChangedPaths is the number of changed paths listed in the
+// warnings of plans changing dashboard JSON.
+const maxDashboardJSONChangedPaths = 20
+
+// resourceDashboardJSONPlanWarnings summarizes the changes to the dashboard
+// JSON of a planned update, listing the paths of the fields which change, such
+// as sections[2].rows[0].charts[1].sources[0].query.
+func resourceDashboardJSONPlanWarnings(prior, planned cty.Value) diag.Diagnostics {
+	if prior.IsNull() || !planned.IsWhollyKnown() || planned.IsNull() {
+		return nil
+	}
+	before, after := prior.GetAttr("dashboard_json"), planned.GetAttr("dashboard_json")
+	if before.IsNull() || after.IsNull() {
+		return nil
+	}
+	paths := dashboardJSONChangedPaths(before.AsString(), after.AsString())
+	if len(paths) == 0 {
+		return nil
+	}
+
+	var detail strings.Builder
+	detail.WriteString("dashboard_json changes at:")
+	for i, path := range paths {
+		if i == maxDashboardJSONChangedPaths {
+			fmt.Fprintf(&detail, "\n  ... and %d more", len(paths)-i)
+			break
+		}
+		detail.WriteString("\n  " + path)
+	}
+	summary := "Wavefront Dashboard changes"
+	if id := prior.GetAttr("id"); !id.IsNull() {
+		summary = fmt.Sprintf("Wavefront Dashboard %s changes", id.AsString())
+	}
+	return diag.Diagnostics{{
+		Severity:      diag.Warning,
+		Summary:       summary,
+		Detail:        detail.String(),
+		AttributePath: cty.GetAttrPath("dashboard_json"),
+	}}
+}
+
+// dashboardJSONChangedPaths returns the paths of the fields which differ
+// between the dashboard JSON before and after, in order. Both are normalized
+// first, so changes Wavefront would ignore aren't listed.
+func dashboardJSONChangedPaths(before, after string) []string {
+	var beforeValue, afterValue interface{}
+	if json.Unmarshal([]byte(NormalizeDashboardJSON(before)), &beforeValue) != nil ||
+		json.Unmarshal([]byte(NormalizeDashboardJSON(after)), &afterValue) != nil {
+		return nil
+	}
+	return appendChangedPaths(nil, "", beforeValue, afterValue)
+}
+
+func appendChangedPaths(paths []string, path string, before, after interface{}) []string {
+	switch b := before.(type) {
+	case map[string]interface{}:
+		if a, ok := after.(map[string]interface{}); ok {
+			keys := make([]string, 0, len(a)+len(b))
+			for key := range b {
+				keys = append(keys, key)
+			}
+			for key := range a {
+				if _, found := b[key]; !found {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				paths = appendChangedPaths(paths, childPath, b[key], a[key])
+			}
+			return paths
+		}
+	case []interface{}:
+		if a, ok := after.([]interface{}); ok {
+			for i := 0; i < len(a) || i < len(b); i++ {
+				childPath := fmt.Sprintf("%s[%d]", path, i)
+				switch {
+				case i >= len(a) || i >= len(b):
+					paths = append(paths, childPath)
+				default:
+					paths = appendChangedPaths(paths, childPath, b[i], a[i])
+				}
+			}
+			return paths
+		}
+	}
+	if !reflect.DeepEqual(before, after) {
+		paths = append(paths, path)
+	}
+	return paths
 }
@@ -1,13 +1,14 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"sort"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -26,9 +27,10 @@ func resourceDashboard() *schema.Resource {
 					Description: "Name of the Source",
 				},
 				"query": {
-					Type:        schema.TypeString,
-					Required:    true,
-					Description: "Query for the Source",
+					Type:             schema.TypeString,
+					Required:         true,
+					Description:      "Query for the Source",
+					ValidateDiagFunc: validateQuery,
 				},
 				"disabled": {
 					Type:        schema.TypeBool,
@@ -483,13 +485,15 @@ func resourceDashboard() *schema.Resource {
 	}
 
 	return &schema.Resource{
-		Create: resourceDashboardCreate,
-		Read:   resourceDashboardRead,
-		Update: resourceDashboardUpdate,
-		Delete: resourceDashboardDelete,
+		CreateContext: resourceDashboardCreate,
+		ReadContext:   resourceDashboardRead,
+		UpdateContext: resourceDashboardUpdate,
+		DeleteContext: resourceDashboardDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffTagsAll,
+		Timeouts:      resourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -523,9 +527,11 @@ func resourceDashboard() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			tagsAllKey: tagsAllSchema(),
 			"can_view": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"can_modify": {
@@ -996,8 +1002,8 @@ func buildParameterDetails(terraformParams *[]interface{}) *map[string]wavefront
 }
 
 // Construct a Wavefront Dashboard
-func buildDashboard(d *schema.ResourceData) (*wavefront.Dashboard, error) {
-	tags := decodeTags(d)
+func buildDashboard(d *schema.ResourceData, meta interface{}) (*wavefront.Dashboard, error) {
+	tags := resourceTags(d, meta)
 	terraformSections := d.Get("section").([]interface{})
 	terraformParams := d.Get("parameter_details").([]interface{})
 	eventFilterType := "BYCHART"
@@ -1030,17 +1036,17 @@ func buildDashboard(d *schema.ResourceData) (*wavefront.Dashboard, error) {
 }
 
 // Create a Terraform Dashboard
-func resourceDashboardCreate(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
-	dashboard, err := buildDashboard(d)
+func resourceDashboardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
+	dashboard, err := buildDashboard(d, meta)
 
 	if err != nil {
-		return fmt.Errorf("failed to parse dashboard, %s", err)
+		return diag.Errorf("failed to parse dashboard, %s", err)
 	}
 
 	err = dashboards.Create(dashboard)
 	if err != nil {
-		return fmt.Errorf("failed to create dashboard, %s", err)
+		return diag.Errorf("failed to create dashboard, %s", err)
 	}
 	d.SetId(dashboard.ID)
 
@@ -1048,11 +1054,11 @@ func resourceDashboardCreate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChanges("can_view", "can_modify") {
 		err = dashboards.SetACL(dashboard.ID, canView, canModify)
 		if err != nil {
-			return fmt.Errorf("error setting ACL on Dashboard %s. %s", d.Get("name"), err)
+			return diag.Errorf("error setting ACL on Dashboard %s. %s", d.Get("name"), err)
 		}
 	}
 
-	return resourceDashboardRead(d, meta)
+	return resourceDashboardRead(ctx, d, meta)
 }
 
 type Params []map[string]interface{}
@@ -1064,8 +1070,8 @@ func (p Params) Less(i, j int) bool {
 }
 
 // Read a Wavefront Dashboard
-func resourceDashboardRead(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+func resourceDashboardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dash := wavefront.Dashboard{
 		ID: d.Id(),
 	}
@@ -1077,7 +1083,7 @@ func resourceDashboardRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
 	}
 
 	// Use the Wavefront url as the Terraform ID
@@ -1097,7 +1103,9 @@ func resourceDashboardRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	d.Set("section", sections)
 	d.Set("parameter_details", parameterDetails)
-	d.Set("tags", dash.Tags)
+	if err = setResourceTags(d, meta, dash.Tags); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set("can_view", dash.ACL.CanView)
 	d.Set("can_modify", dash.ACL.CanModify)
 
@@ -1150,25 +1158,24 @@ func inSlice(needle string, haystack []string) int {
 	return -1
 }
 
-func resourceDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+func resourceDashboardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 
-	a, err := buildDashboard(d)
+	a, err := buildDashboard(d, meta)
 	if err != nil {
-		return fmt.Errorf("failed to parse dashboard, %s", err)
+		return diag.Errorf("failed to parse dashboard, %s", err)
 	}
 
 	// Update the dashboard on Wavefront
 	err = dashboards.Update(a)
 	if err != nil {
-		return fmt.Errorf("error Updating Dashboard %s. %s", d.Get("name"), err)
+		return diag.Errorf("error Updating Dashboard %s. %s", d.Get("name"), err)
 	}
 
-	if d.HasChange("tags") {
-		tags := decodeTags(d)
-		err = dashboards.SetTags(a.ID, tags)
+	if d.HasChanges("tags", tagsAllKey) {
+		err = dashboards.SetTags(a.ID, a.Tags)
 		if err != nil {
-			return fmt.Errorf("unable to update the tags for the Wavefront Dashboard")
+			return diag.Errorf("unable to update the tags for the Wavefront Dashboard")
 		}
 	}
 
@@ -1177,27 +1184,27 @@ func resourceDashboardUpdate(d *schema.ResourceData, meta interface{}) error {
 
 		err = dashboards.SetACL(d.Id(), canView, canModify)
 		if err != nil {
-			return fmt.Errorf("error updating ACLs for Wavefront Dashboards")
+			return diag.Errorf("error updating ACLs for Wavefront Dashboards")
 		}
 	}
-	return resourceDashboardRead(d, meta)
+	return resourceDashboardRead(ctx, d, meta)
 }
 
-func resourceDashboardDelete(d *schema.ResourceData, meta interface{}) error {
-	dashboards := meta.(*wavefrontClient).client.Dashboards()
+func resourceDashboardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
 	dash := wavefront.Dashboard{
 		ID: d.Id(),
 	}
 
 	err := dashboards.Get(&dash)
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
 	}
 
 	// Delete the Dashboard
 	err = dashboards.Delete(&dash, true)
 	if err != nil {
-		return fmt.Errorf("failed to delete Dashboard %s. %s", d.Id(), err)
+		return diag.Errorf("failed to delete Dashboard %s. %s", d.Id(), err)
 	}
 	d.SetId("")
 	return nil
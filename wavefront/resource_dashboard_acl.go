@@ -0,0 +1,70 @@
+package wavefront
+
+import (
+	"context"
+	"log"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceDashboardACL manages the access control list of a dashboard, so
+// that it can be managed apart from the dashboard. The can_view and
+// can_modify of the wavefront_dashboard, or the acl of the
+// wavefront_dashboard_json, must then be left unset.
+func resourceDashboardACL() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDashboardACLSet,
+		ReadContext:   resourceDashboardACLRead,
+		UpdateContext: resourceDashboardACLSet,
+		DeleteContext: resourceDashboardACLDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema:   aclResourceSchema(dashboardIDKey),
+	}
+}
+
+func resourceDashboardACLSet(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
+	dashboardID := d.Get(dashboardIDKey).(string)
+	canView, canModify := decodeAccessControlList(d)
+
+	err := dashboards.SetACL(dashboardID, canView, canModify)
+	if err != nil {
+		return diag.Errorf("error setting ACL on Dashboard %s. %s", dashboardID, err)
+	}
+	d.SetId(dashboardID)
+	log.Printf("[INFO] Set the ACL of Wavefront Dashboard %s", d.Id())
+
+	return resourceDashboardACLRead(ctx, d, meta)
+}
+
+func resourceDashboardACLRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
+	dash := wavefront.Dashboard{ID: d.Id()}
+	err := dashboards.Get(&dash)
+	if err != nil {
+		if wavefront.NotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error finding Wavefront Dashboard %s. %s", d.Id(), err)
+	}
+
+	return diag.FromErr(setAccessControlList(d, dashboardIDKey, d.Id(), dash.ACL))
+}
+
+// resourceDashboardACLDelete clears the access control list of the
+// dashboard, so that it's visible to and modifiable by everyone again.
+func resourceDashboardACLDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	dashboards := meta.(*wavefrontClient).withContext(ctx).Dashboards()
+	err := dashboards.SetACL(d.Id(), []string{}, []string{})
+	if err != nil && !wavefront.NotFound(err) {
+		return diag.Errorf("error clearing ACL on Dashboard %s. %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,101 @@
+package wavefront
+
+import (
+	"context"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardACL_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+
+	dashboardJSON := resourceDashboardJSON()
+	dj := schema.TestResourceDataRaw(t, dashboardJSON.Schema, map[string]interface{}{
+		"dashboard_json": testDashboardJSON,
+	})
+	diags := dashboardJSON.CreateContext(context.Background(), dj, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+
+	dashboardACL := resourceDashboardACL()
+	d := schema.TestResourceDataRaw(t, dashboardACL.Schema, map[string]interface{}{
+		dashboardIDKey: dj.Id(),
+		canModifyKey:   []interface{}{"security"},
+	})
+	diags = dashboardACL.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, dj.Id(), d.Id())
+
+	// the dashboard_json without an acl neither plans nor makes ACL changes
+	stateJSON := dj.Get("dashboard_json").(string)
+	diags = dashboardJSON.ReadContext(context.Background(), dj, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, stateJSON, dj.Get("dashboard_json"))
+	diags = dashboardJSON.UpdateContext(context.Background(), dj, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	dash := wavefront.Dashboard{ID: dj.Id()}
+	require.NoError(t, client.Dashboards().Get(&dash))
+	assert.Equal(t, []string{"security"}, dash.ACL.CanModify)
+
+	diags = dashboardACL.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NoError(t, client.Dashboards().Get(&dash))
+	assert.True(t, isEmptyACL(dash.ACL), "%v", dash.ACL)
+}
+
+func TestAccWavefrontDashboardACL_Basic(t *testing.T) {
+	resourceName := "wavefront_dashboard_acl.acl"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontDashboardJSONDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontDashboardACL(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "dashboard_id", "wavefront_dashboard_json.dashboard", "id"),
+					resource.TestCheckResourceAttr(resourceName, "can_modify.#", "1"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontDashboardACL() string {
+	return `
+resource "wavefront_user" "owner" {
+  email = "dashboard-acl+tftesting@example.com"
+}
+
+resource "wavefront_dashboard_json" "dashboard" {
+  dashboard_json = jsonencode({
+    name = "Terraform Test Dashboard ACL"
+    url  = "tftestdashboardacl"
+    sections = [{
+      name = "section 1"
+      rows = [{
+        charts = [{
+          name    = "chart 1"
+          sources = [{ name = "source 1", query = "ts(cpu.usage)" }]
+        }]
+      }]
+    }]
+  })
+}
+
+resource "wavefront_dashboard_acl" "acl" {
+  dashboard_id = wavefront_dashboard_json.dashboard.id
+  can_modify   = [wavefront_user.owner.id]
+}
+`
+}
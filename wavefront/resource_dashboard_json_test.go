@@ -2,13 +2,81 @@ package wavefront
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
+const testDashboardJSON = `{
+	"name": "Terraform Test Dashboard Json",
+	"url": "tftestimport",
+	"eventFilterType": "BYCHART",
+	"displaySectionTableOfContents": true,
+	"tags": {"customerTags": ["b", "a"]},
+	"sections": [{
+		"name": "section 1",
+		"rows": [{
+			"charts": [{
+				"name": "chart 1",
+				"summarization": "MEAN",
+				"chartAttributes": {"legend": {"enabled": false}, "dashboardLinks": {}},
+				"chartSettings": {"type": "line", "max": 100},
+				"sources": [{"name": "source 1", "query": "ts(cpu.usage) > 90"}]
+			}]
+		}]
+	}]
+}`
+
+func TestNormalizeDashboardJSON(t *testing.T) {
+	normalized := NormalizeDashboardJSON(testDashboardJSON)
+	assert.Equal(t, `{"displaySectionTableOfContents":true,"id":"tftestimport","name":"Terraform Test Dashboard Json",`+
+		`"sections":[{"name":"section 1","rows":[{"charts":[{"chartAttributes":{"dashboardLinks":{},"legend":{"enabled":false}},`+
+		`"chartSettings":{"max":100},"name":"chart 1","sources":[{"name":"source 1","query":"ts(cpu.usage) > 90"}]}]}]}],`+
+		`"tags":{"customerTags":["a","b"]},"url":"tftestimport"}`, normalized)
+	assert.Equal(t, normalized, NormalizeDashboardJSON(normalized), "normalization must be stable")
+
+	fromServer := `{
+		"url": "tftestimport", "id": "tftestimport", "name": "Terraform Test Dashboard Json",
+		"displaySectionTableOfContents": true, "displayDescription": false, "defaultTimeWindow": "",
+		"creatorId": "jane@example.com", "updatedEpochMillis": 1700000000000, "viewsLastDay": 3, "numCharts": 1,
+		"parameters": {}, "parameterDetails": {}, "acl": {"canView": [], "canModify": []},
+		"tags": {"customerTags": ["a", "b"]},
+		"sections": [{"name": "section 1", "rows": [{"heightFactor": 50, "name": "", "charts": [{
+			"sources": [{"query": "ts(cpu.usage) > 90", "name": "source 1", "scatterPlotSource": "Y",
+				"querybuilderEnabled": false, "sourceDescription": ""}],
+			"chartSettings": {"max": 100, "type": "line"}, "base": 0, "units": "",
+			"chartAttributes": {"dashboardLinks": {}, "legend": {"enabled": false}},
+			"name": "chart 1", "description": ""}]}]}]
+	}`
+	assert.Equal(t, normalized, NormalizeDashboardJSON(fromServer))
+}
+
+func TestDashboardJSONChangedPaths(t *testing.T) {
+	assert.Empty(t, dashboardJSONChangedPaths(testDashboardJSON, NormalizeDashboardJSON(testDashboardJSON)))
+
+	changed := strings.NewReplacer(
+		`"query": "ts(cpu.usage) > 90"`, `"query": "ts(cpu.usage) > 95"`,
+		`"max": 100`, `"max": 100, "min": 10`,
+		`"tags": {"customerTags": ["b", "a"]},`, ``,
+	).Replace(testDashboardJSON)
+	assert.Equal(t, []string{
+		"sections[0].rows[0].charts[0].chartSettings.min",
+		"sections[0].rows[0].charts[0].sources[0].query",
+		"tags",
+	}, dashboardJSONChangedPaths(testDashboardJSON, changed))
+
+	added := strings.Replace(testDashboardJSON, `"sources": [{`, `"sources": [{"name": "source 0"}, {`, 1)
+	assert.Equal(t, []string{
+		"sections[0].rows[0].charts[0].sources[0].name",
+		"sections[0].rows[0].charts[0].sources[0].query",
+		"sections[0].rows[0].charts[0].sources[1]",
+	}, dashboardJSONChangedPaths(testDashboardJSON, added))
+}
+
 func TestAccWavefrontDashboardJson_Basic(t *testing.T) {
 	var record wavefront.Dashboard
 	resource.Test(t, resource.TestCase{
@@ -1,30 +1,34 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceDerivedMetric() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceDerivedMetricCreate,
-		Read:   resourceDerivedMetricRead,
-		Update: resourceDerivedMetricUpdate,
-		Delete: resourceDerivedMetricDelete,
+		CreateContext: resourceDerivedMetricCreate,
+		ReadContext:   resourceDerivedMetricRead,
+		UpdateContext: resourceDerivedMetricUpdate,
+		DeleteContext: resourceDerivedMetricDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffTagsAll,
+		Timeouts:      resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
 			},
 			"query": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateQuery,
 			},
 			"minutes": {
 				Type:         schema.TypeInt,
@@ -40,17 +44,15 @@ func resourceDerivedMetric() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			tagsAllKey: tagsAllSchema(),
 		},
 	}
 }
 
-func resourceDerivedMetricCreate(d *schema.ResourceData, meta interface{}) error {
-	derivedMetrics := meta.(*wavefrontClient).client.DerivedMetrics()
+func resourceDerivedMetricCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	derivedMetrics := meta.(*wavefrontClient).withContext(ctx).DerivedMetrics()
 
-	var tags []string
-	for _, tag := range d.Get("tags").(*schema.Set).List() {
-		tags = append(tags, tag.(string))
-	}
+	tags := resourceTags(d, meta)
 
 	dm := &wavefront.DerivedMetric{
 		Name:                  d.Get("name").(string),
@@ -62,16 +64,16 @@ func resourceDerivedMetricCreate(d *schema.ResourceData, meta interface{}) error
 
 	err := derivedMetrics.Create(dm)
 	if err != nil {
-		return fmt.Errorf("error creating Derived Metric %s. %s", d.Get("name"), err)
+		return diag.Errorf("error creating Derived Metric %s. %s", d.Get("name"), err)
 	}
 
 	d.SetId(*dm.ID)
 
-	return resourceDerivedMetricRead(d, meta)
+	return resourceDerivedMetricRead(ctx, d, meta)
 }
 
-func resourceDerivedMetricUpdate(d *schema.ResourceData, meta interface{}) error {
-	derivedMetrics := meta.(*wavefrontClient).client.DerivedMetrics()
+func resourceDerivedMetricUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	derivedMetrics := meta.(*wavefrontClient).withContext(ctx).DerivedMetrics()
 
 	derivedMetricID := d.Id()
 	tmpDM := &wavefront.DerivedMetric{ID: &derivedMetricID}
@@ -82,10 +84,7 @@ func resourceDerivedMetricUpdate(d *schema.ResourceData, meta interface{}) error
 		return nil
 	}
 
-	var tags []string
-	for _, tag := range d.Get("tags").(*schema.Set).List() {
-		tags = append(tags, tag.(string))
-	}
+	tags := resourceTags(d, meta)
 
 	dm := tmpDM
 	dm.Name = d.Get("name").(string)
@@ -96,14 +95,14 @@ func resourceDerivedMetricUpdate(d *schema.ResourceData, meta interface{}) error
 
 	err = derivedMetrics.Update(dm)
 	if err != nil {
-		return fmt.Errorf("unable to update Wavefront Derived Metric %s, %s", derivedMetricID, err)
+		return diag.Errorf("unable to update Wavefront Derived Metric %s, %s", derivedMetricID, err)
 	}
 
-	return resourceDerivedMetricRead(d, meta)
+	return resourceDerivedMetricRead(ctx, d, meta)
 }
 
-func resourceDerivedMetricRead(d *schema.ResourceData, meta interface{}) error {
-	derivedMetrics := meta.(*wavefrontClient).client.DerivedMetrics()
+func resourceDerivedMetricRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	derivedMetrics := meta.(*wavefrontClient).withContext(ctx).DerivedMetrics()
 
 	derivedMetricID := d.Id()
 	tmpDM := &wavefront.DerivedMetric{ID: &derivedMetricID}
@@ -114,7 +113,7 @@ func resourceDerivedMetricRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find Wavefront Derived Metric %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find Wavefront Derived Metric %s. %s", d.Id(), err)
 	}
 
 	d.SetId(*tmpDM.ID)
@@ -122,13 +121,15 @@ func resourceDerivedMetricRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("minutes", tmpDM.Minutes)
 	d.Set("additional_information", tmpDM.AdditionalInformation)
 	d.Set("query", tmpDM.Query)
-	d.Set("tags", tmpDM.Tags.CustomerTags)
+	if err = setResourceTags(d, meta, tmpDM.Tags.CustomerTags); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
 
-func resourceDerivedMetricDelete(d *schema.ResourceData, meta interface{}) error {
-	derivedMetrics := meta.(*wavefrontClient).client.DerivedMetrics()
+func resourceDerivedMetricDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	derivedMetrics := meta.(*wavefrontClient).withContext(ctx).DerivedMetrics()
 
 	derivedMetricID := d.Id()
 	tmpDM := &wavefront.DerivedMetric{ID: &derivedMetricID}
@@ -139,12 +140,12 @@ func resourceDerivedMetricDelete(d *schema.ResourceData, meta interface{}) error
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find Wavefront Derived Metric %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find Wavefront Derived Metric %s. %s", d.Id(), err)
 	}
 
 	err = derivedMetrics.Delete(tmpDM, true)
 	if err != nil {
-		return fmt.Errorf("error trying to delete Wavefront Derived Metric %s. %s", d.Id(), err)
+		return diag.Errorf("error trying to delete Wavefront Derived Metric %s. %s", d.Id(), err)
 	}
 
 	d.SetId("")
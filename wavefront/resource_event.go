@@ -1,22 +1,25 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceEvent() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceEventCreate,
-		Read:   resourceEventRead,
-		Update: resourceEventUpdate,
-		Delete: resourceEventDelete,
+		CreateContext: resourceEventCreate,
+		ReadContext:   resourceEventRead,
+		UpdateContext: resourceEventUpdate,
+		DeleteContext: resourceEventDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customizeDiffTagsAll,
+		Timeouts:      resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			nameKey: {
 				Type:     schema.TypeString,
@@ -42,13 +45,14 @@ func resourceEvent() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			tagsAllKey: tagsAllSchema(),
 		},
 	}
 }
 
-func resourceEventRead(d *schema.ResourceData, meta interface{}) error {
+func resourceEventRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	events := meta.(*wavefrontClient).client.Events()
+	events := meta.(*wavefrontClient).withContext(ctx).Events()
 
 	eventID := d.Id()
 	tmpEvent, err := events.FindByID(eventID)
@@ -58,24 +62,26 @@ func resourceEventRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find Wavefront Event %s. %s", d.Id(), err)
+		return diag.Errorf("unable to find Wavefront Event %s. %s", d.Id(), err)
 	}
 
 	d.SetId(*tmpEvent.ID)
 	d.Set(nameKey, tmpEvent.Name)
 	d.Set(startTimeKey, tmpEvent.StartTime)
 	d.Set(endTimeKey, tmpEvent.EndTime)
-	d.Set(tagsKey, tmpEvent.Tags)
+	if err = setResourceTags(d, meta, tmpEvent.Tags); err != nil {
+		return diag.FromErr(err)
+	}
 	d.Set(annotationsKey, tmpEvent.Annotations)
 
 	return nil
 
 }
 
-func resourceEventCreate(d *schema.ResourceData, meta interface{}) error {
-	events := meta.(*wavefrontClient).client.Events()
+func resourceEventCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	events := meta.(*wavefrontClient).withContext(ctx).Events()
 
-	tags := decodeEventTags(d)
+	tags := resourceTags(d, meta)
 	event := &wavefront.Event{
 		Name:        d.Get(nameKey).(string),
 		StartTime:   int64(d.Get(startTimeKey).(int)),
@@ -87,7 +93,7 @@ func resourceEventCreate(d *schema.ResourceData, meta interface{}) error {
 	// Create the Event on Wavefront
 	err := events.Create(event)
 	if err != nil {
-		return fmt.Errorf("error creating Event %s. %s", d.Get(nameKey), err)
+		return diag.Errorf("error creating Event %s. %s", d.Get(nameKey), err)
 	}
 
 	d.SetId(*event.ID)
@@ -95,8 +101,8 @@ func resourceEventCreate(d *schema.ResourceData, meta interface{}) error {
 
 }
 
-func resourceEventUpdate(d *schema.ResourceData, meta interface{}) error {
-	events := meta.(*wavefrontClient).client.Events()
+func resourceEventUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	events := meta.(*wavefrontClient).withContext(ctx).Events()
 
 	eventID := d.Id()
 	newEvent, err := events.FindByID(eventID)
@@ -116,8 +122,8 @@ func resourceEventUpdate(d *schema.ResourceData, meta interface{}) error {
 		newEvent.StartTime = int64(d.Get(endTimeKey).(int))
 	}
 
-	if d.HasChange(tagsKey) {
-		newEvent.Tags = decodeEventTags(d)
+	if d.HasChanges(tagsKey, tagsAllKey) {
+		newEvent.Tags = resourceTags(d, meta)
 	}
 
 	if d.HasChange(annotationsKey) {
@@ -126,15 +132,15 @@ func resourceEventUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	err = events.Update(newEvent)
 	if err != nil {
-		return fmt.Errorf("unable to update Wavefront Event %s, %s", d.Get(nameKey), err)
+		return diag.Errorf("unable to update Wavefront Event %s, %s", d.Get(nameKey), err)
 	}
 
-	return resourceEventRead(d, meta)
+	return resourceEventRead(ctx, d, meta)
 }
 
-func resourceEventDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceEventDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	events := meta.(*wavefrontClient).client.Events()
+	events := meta.(*wavefrontClient).withContext(ctx).Events()
 	var err error
 
 	eventID := d.Id()
@@ -145,23 +151,14 @@ func resourceEventDelete(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return fmt.Errorf("unable to find Wavefront Event %s. %s", d.Get(nameKey), err)
+		return diag.Errorf("unable to find Wavefront Event %s. %s", d.Get(nameKey), err)
 	}
 
 	err = events.Delete(newEvent)
 	if err != nil {
-		return fmt.Errorf("error trying to delete Wavefront Event %s. %s", d.Get(nameKey), err)
+		return diag.Errorf("error trying to delete Wavefront Event %s. %s", d.Get(nameKey), err)
 	}
 
 	d.SetId("")
 	return nil
 }
-
-// Decodes the tags from the state and returns a []string of tags
-func decodeEventTags(d *schema.ResourceData) []string {
-	var tags []string
-	for _, tag := range d.Get("tags").([]interface{}) {
-		tags = append(tags, tag.(string))
-	}
-	return tags
-}
@@ -1,9 +1,10 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,13 +20,14 @@ const (
 
 func resourceExternalLink() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceExternalLinkCreate,
-		Read:   resourceExternalLinkRead,
-		Update: resourceExternalLinkUpdate,
-		Delete: resourceExternalLinkDelete,
+		CreateContext: resourceExternalLinkCreate,
+		ReadContext:   resourceExternalLinkRead,
+		UpdateContext: resourceExternalLinkUpdate,
+		DeleteContext: resourceExternalLinkDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			elNameKey: {
 				Type:     schema.TypeString,
@@ -60,9 +62,8 @@ func resourceExternalLink() *schema.Resource {
 	}
 }
 
-func resourceExternalLinkCreate(
-	d *schema.ResourceData, meta interface{}) error {
-	externalLinks := meta.(*wavefrontClient).client.ExternalLinks()
+func resourceExternalLinkCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	externalLinks := meta.(*wavefrontClient).withContext(ctx).ExternalLinks()
 
 	externalLink := wavefront.ExternalLink{
 		Name:                  d.Get(elNameKey).(string),
@@ -75,16 +76,15 @@ func resourceExternalLinkCreate(
 	}
 	err := externalLinks.Create(&externalLink)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"failed to create new Wavefront External Link, %s", err)
 	}
 	d.SetId(*externalLink.ID)
-	return resourceExternalLinkRead(d, meta)
+	return resourceExternalLinkRead(ctx, d, meta)
 }
 
-func resourceExternalLinkRead(
-	d *schema.ResourceData, meta interface{}) error {
-	externalLinks := meta.(*wavefrontClient).client.ExternalLinks()
+func resourceExternalLinkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	externalLinks := meta.(*wavefrontClient).withContext(ctx).ExternalLinks()
 	id := d.Id()
 	el := wavefront.ExternalLink{ID: &id}
 	err := externalLinks.Get(&el)
@@ -93,36 +93,35 @@ func resourceExternalLinkRead(
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error finding Wavefront External Link, %s. %s",
 			d.Id(),
 			err)
 	}
 	if err := d.Set(elNameKey, el.Name); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(elDescriptionKey, el.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(elTemplateKey, el.Template); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(elMetricFilterRegexKey, el.MetricFilterRegex); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(elSourceFilterRegexKey, el.SourceFilterRegex); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = setStringMap(d, elPointTagFilterRegexesKey, el.PointTagFilterRegexes)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return d.Set(elIsLogIntegrationKey, el.IsLogIntegration)
+	return diag.FromErr(d.Set(elIsLogIntegrationKey, el.IsLogIntegration))
 }
 
-func resourceExternalLinkUpdate(
-	d *schema.ResourceData, meta interface{}) error {
-	externalLinks := meta.(*wavefrontClient).client.ExternalLinks()
+func resourceExternalLinkUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	externalLinks := meta.(*wavefrontClient).withContext(ctx).ExternalLinks()
 	id := d.Id()
 	el := wavefront.ExternalLink{ID: &id}
 	err := externalLinks.Get(&el)
@@ -131,7 +130,7 @@ func resourceExternalLinkUpdate(
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(""+
+		return diag.Errorf(""+
 			"error finding Wavefront External Link, %s. %s",
 			d.Id(),
 			err)
@@ -159,23 +158,22 @@ func resourceExternalLinkUpdate(
 	}
 	err = externalLinks.Update(&el)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error updating Wavefront External Link,  %s. %s",
 			d.Id(),
 			err,
 		)
 	}
-	return resourceExternalLinkRead(d, meta)
+	return resourceExternalLinkRead(ctx, d, meta)
 }
 
-func resourceExternalLinkDelete(
-	d *schema.ResourceData, meta interface{}) error {
-	externalLinks := meta.(*wavefrontClient).client.ExternalLinks()
+func resourceExternalLinkDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	externalLinks := meta.(*wavefrontClient).withContext(ctx).ExternalLinks()
 	id := d.Id()
 	el := wavefront.ExternalLink{ID: &id}
 	err := externalLinks.Delete(&el)
 	if err != nil && !wavefront.NotFound(err) {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error deleting Wavefront External Link, %s. %s",
 			d.Id(),
 			err,
@@ -1,10 +1,10 @@
 package wavefront
 
 import (
-	"errors"
-	"fmt"
+	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -29,13 +29,14 @@ const (
 func resourceIngestionPolicy() *schema.Resource {
 
 	return &schema.Resource{
-		Create: resourceIngestionPolicyCreate,
-		Read:   resourceIngestionPolicyRead,
-		Update: resourceIngestionPolicyUpdate,
-		Delete: resourceIngestionPolicyDelete,
+		CreateContext: resourceIngestionPolicyCreate,
+		ReadContext:   resourceIngestionPolicyRead,
+		UpdateContext: resourceIngestionPolicyUpdate,
+		DeleteContext: resourceIngestionPolicyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			ipNameKey: {
 				Type:     schema.TypeString,
@@ -130,9 +131,9 @@ func parseIngestionPolicyTags(raw interface{}) []map[string]string {
 }
 
 // CRUD
-func resourceIngestionPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceIngestionPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*wavefrontClient).client.IngestionPolicies()
+	client := meta.(*wavefrontClient).withContext(ctx).IngestionPolicies()
 	newPolicyRequest := wavefront.IngestionPolicyRequest{
 		Name:        d.Get(ipNameKey).(string),
 		Description: d.Get(ipDescriptionKey).(string),
@@ -146,16 +147,16 @@ func resourceIngestionPolicyCreate(d *schema.ResourceData, meta interface{}) err
 	ingestionPolicy, err := client.Create(&newPolicyRequest)
 
 	if err != nil {
-		return fmt.Errorf("failed to create ingestion policy, %s", err)
+		return diag.Errorf("failed to create ingestion policy, %s", err)
 	}
 
 	d.SetId(ingestionPolicy.ID)
-	return resourceIngestionPolicyRead(d, meta)
+	return resourceIngestionPolicyRead(ctx, d, meta)
 }
 
-func resourceIngestionPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceIngestionPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*wavefrontClient).client.IngestionPolicies()
+	client := meta.(*wavefrontClient).withContext(ctx).IngestionPolicies()
 	ingestionPolicy, err := client.GetByID(d.Id())
 
 	if wavefront.NotFound(err) {
@@ -164,65 +165,60 @@ func resourceIngestionPolicyRead(d *schema.ResourceData, meta interface{}) error
 	}
 
 	if err != nil {
-		return fmt.Errorf("an error happened fetching the ingestion policy, %s. %s", d.Id(), err)
+		return diag.Errorf("an error happened fetching the ingestion policy, %s. %s", d.Id(), err)
 	}
 
-	if err = d.Set(ipNameKey, ingestionPolicy.Name); err != nil {
-		return err
+	switch {
+	case ingestionPolicy.Scope == "ACCOUNT" && len(ingestionPolicy.Accounts) < 1:
+		return diagFromErr(newAttributeError(ipAccountsKey, "ingestion policy account scope must have at least one associated account"))
+	case ingestionPolicy.Scope == "GROUP" && len(ingestionPolicy.Groups) < 1:
+		return diagFromErr(newAttributeError(ipGroupsKey, "ingestion policy group scope must have at least one associated group"))
 	}
 
-	if err = d.Set(ipDescriptionKey, ingestionPolicy.Description); err != nil {
-		return err
+	for key, value := range flattenIngestionPolicy(ingestionPolicy) {
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
-	if err = d.Set(ipScopeKey, ingestionPolicy.Scope); err != nil {
-		return err
+	return nil
+}
+
+// flattenIngestionPolicy returns the attributes of the ingestion policy,
+// shared by the resource and the data sources. Only the field of its scope is
+// set among the accounts, groups, sources, namespaces and tags.
+func flattenIngestionPolicy(ingestionPolicy *wavefront.IngestionPolicyResponse) map[string]interface{} {
+	tfMap := map[string]interface{}{
+		ipNameKey:        ingestionPolicy.Name,
+		ipDescriptionKey: ingestionPolicy.Description,
+		ipScopeKey:       ingestionPolicy.Scope,
 	}
 
 	switch ingestionPolicy.Scope {
 
 	case "ACCOUNT":
-		accounts := flattenIngestionPolicyAccountIDs(ingestionPolicy.Accounts)
-		if len(accounts) < 1 {
-			return errors.New("ingestion policy account scope must have at least one associated account")
-		}
-		if err = d.Set(ipAccountsKey, accounts); err != nil {
-			return err
-		}
+		tfMap[ipAccountsKey] = flattenIngestionPolicyAccountIDs(ingestionPolicy.Accounts)
 
 	case "GROUP":
-		groups := flattenIngestionPolicyGroupIDs(ingestionPolicy.Groups)
-		if len(groups) < 1 {
-			return errors.New("ingestion policy group scope must have at least one associated group")
-		}
-		if err = d.Set(ipGroupsKey, groups); err != nil {
-			return err
-		}
+		tfMap[ipGroupsKey] = flattenIngestionPolicyGroupIDs(ingestionPolicy.Groups)
 
 	case "SOURCES":
-		if err = d.Set(ipSourcesKey, ingestionPolicy.Sources); err != nil {
-			return err
-		}
+		tfMap[ipSourcesKey] = ingestionPolicy.Sources
 
 	case "NAMESPACES":
-		if err = d.Set(ipNamespacesKey, ingestionPolicy.Namespaces); err != nil {
-			return err
-		}
+		tfMap[ipNamespacesKey] = ingestionPolicy.Namespaces
 
 	case "TAGS":
-		tags := convertIngestionPolicyTagsToMap(ingestionPolicy.Tags)
-		if err = d.Set(ipTagsKey, tags); err != nil {
-			return err
-		}
+		tfMap[ipTagsKey] = convertIngestionPolicyTagsToMap(ingestionPolicy.Tags)
 
 	}
 
-	return nil
+	return tfMap
 }
 
-func resourceIngestionPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceIngestionPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*wavefrontClient).client.IngestionPolicies()
+	client := meta.(*wavefrontClient).withContext(ctx).IngestionPolicies()
 	policy, err := client.GetByID(d.Id())
 
 	if wavefront.NotFound(err) {
@@ -231,7 +227,7 @@ func resourceIngestionPolicyUpdate(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if err != nil {
-		return fmt.Errorf("an error happened fetching the ingestion policy, %s. %s", d.Id(), err)
+		return diag.Errorf("an error happened fetching the ingestion policy, %s. %s", d.Id(), err)
 	}
 
 	policy.Name = d.Get(ipNameKey).(string)
@@ -246,19 +242,19 @@ func resourceIngestionPolicyUpdate(d *schema.ResourceData, meta interface{}) err
 	err = client.Update(policy)
 
 	if err != nil {
-		return fmt.Errorf("error updating ingestion policy,  %s. %s", d.Id(), err)
+		return diag.Errorf("error updating ingestion policy,  %s. %s", d.Id(), err)
 	}
 
-	return resourceIngestionPolicyRead(d, meta)
+	return resourceIngestionPolicyRead(ctx, d, meta)
 }
 
-func resourceIngestionPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceIngestionPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	client := meta.(*wavefrontClient).client.IngestionPolicies()
+	client := meta.(*wavefrontClient).withContext(ctx).IngestionPolicies()
 	err := client.DeleteByID(d.Id())
 
 	if err != nil && !wavefront.NotFound(err) {
-		return fmt.Errorf("error deleting ingestion policy, %s. %s", d.Id(), err)
+		return diag.Errorf("error deleting ingestion policy, %s. %s", d.Id(), err)
 	}
 
 	d.SetId("")
@@ -1,9 +1,10 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -21,13 +22,14 @@ const (
 
 func resourceMaintenanceWindow() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMaintenanceWindowCreate,
-		Read:   resourceMaintenanceWindowRead,
-		Update: resourceMaintenanceWindowUpdate,
-		Delete: resourceMaintenanceWindowDelete,
+		CreateContext: resourceMaintenanceWindowCreate,
+		ReadContext:   resourceMaintenanceWindowRead,
+		UpdateContext: resourceMaintenanceWindowUpdate,
+		DeleteContext: resourceMaintenanceWindowDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			mwReasonKey: {
 				Type:     schema.TypeString,
@@ -77,9 +79,8 @@ func resourceMaintenanceWindow() *schema.Resource {
 	}
 }
 
-func resourceMaintenanceWindowCreate(
-	d *schema.ResourceData, meta interface{}) error {
-	maintenanceWindows := meta.(*wavefrontClient).client.MaintenanceWindows()
+func resourceMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
 
 	mw, err := maintenanceWindows.Create(
 		&wavefront.MaintenanceWindowOptions{
@@ -94,70 +95,68 @@ func resourceMaintenanceWindowCreate(
 			HostTagGroupHostNamesGroupAnded: d.Get(mwHostTagGroupHostNamesGroupAndedKey).(bool),
 		})
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"failed to create new Wavefront Maintenance Window, %s",
 			err)
 	}
 	d.SetId(mw.ID)
 
-	return resourceMaintenanceWindowRead(d, meta)
+	return resourceMaintenanceWindowRead(ctx, d, meta)
 }
 
-func resourceMaintenanceWindowRead(
-	d *schema.ResourceData, meta interface{}) error {
-	maintenanceWindows := meta.(*wavefrontClient).client.MaintenanceWindows()
+func resourceMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
 	mw, err := maintenanceWindows.GetByID(d.Id())
 	if wavefront.NotFound(err) {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error finding Wavefront Maintenance Window %s. %s",
 			d.Id(),
 			err)
 	}
 	if err := d.Set(mwReasonKey, mw.Reason); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(mwTitleKey, mw.Title); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(mwStartTimeInSecondsKey, int(mw.StartTimeInSeconds)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(mwEndTimeInSecondsKey, int(mw.EndTimeInSeconds)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = setStringSlice(d, mwRelevantCustomerTagsKey, mw.RelevantCustomerTags)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = setStringSlice(d, mwRelevantHostTagsKey, mw.RelevantHostTags)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = setStringSlice(d, mwRelevantHostNamesKey, mw.RelevantHostNames)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set(mwRelevantHostTagsAndedKey, mw.RelevantHostTagsAnded)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return d.Set(mwHostTagGroupHostNamesGroupAndedKey, mw.HostTagGroupHostNamesGroupAnded)
+	return diag.FromErr(d.Set(mwHostTagGroupHostNamesGroupAndedKey, mw.HostTagGroupHostNamesGroupAnded))
 }
 
-func resourceMaintenanceWindowUpdate(
-	d *schema.ResourceData, meta interface{}) error {
-	maintenanceWindows := meta.(*wavefrontClient).client.MaintenanceWindows()
+func resourceMaintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
 	mw, err := maintenanceWindows.GetByID(d.Id())
 	if wavefront.NotFound(err) {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(""+
+		return diag.Errorf(""+
 			"error finding Wavefront Maintenance Window %s. %s",
 			d.Id(),
 			err)
@@ -192,21 +191,20 @@ func resourceMaintenanceWindowUpdate(
 	}
 	_, err = maintenanceWindows.Update(mw.ID, options)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error updating Wavefront Maintenance Window  %s. %s",
 			d.Id(),
 			err,
 		)
 	}
-	return resourceMaintenanceWindowRead(d, meta)
+	return resourceMaintenanceWindowRead(ctx, d, meta)
 }
 
-func resourceMaintenanceWindowDelete(
-	d *schema.ResourceData, meta interface{}) error {
-	maintenanceWindows := meta.(*wavefrontClient).client.MaintenanceWindows()
+func resourceMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
 	err := maintenanceWindows.DeleteByID(d.Id())
 	if err != nil && !wavefront.NotFound(err) {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error deleting Wavefront Maintenance Window %s. %s",
 			d.Id(),
 			err,
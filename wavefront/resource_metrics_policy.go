@@ -1,6 +1,7 @@
 package wavefront
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -13,34 +14,35 @@ import (
 
 func resourceMetricsPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceMetricsPolicyUpdate,
-		Read:   resourceMetricsPolicyRead,
-		Update: resourceMetricsPolicyUpdate,
-		Delete: resourceMetricsPolicyDelete,
+		CreateContext: resourceMetricsPolicyUpdate,
+		ReadContext:   resourceMetricsPolicyRead,
+		UpdateContext: resourceMetricsPolicyUpdate,
+		DeleteContext: resourceMetricsPolicyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Schema: resourceMetricsPolicySchema(),
+		Timeouts: resourceTimeouts(),
+		Schema:   resourceMetricsPolicySchema(),
 	}
 }
 
-func resourceMetricsPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	metrics := meta.(*wavefrontClient).client.MetricsPolicyAPI()
+func resourceMetricsPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
 	metricsPolicy, err := metrics.Get()
 	if err != nil {
-		return fmt.Errorf("error retrieving metrics policy: %d", err)
+		return diag.Errorf("error retrieving metrics policy: %d", err)
 	}
 	d.SetId(strconv.Itoa(metricsPolicy.UpdatedEpochMillis))
 	if err := d.Set(policyRulesKey, flattenPolicyRules(metricsPolicy.PolicyRules)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(customerKey, metricsPolicy.Customer); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set(updaterIDKey, metricsPolicy.UpdaterId); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return d.Set(updatedEpochMillisKey, metricsPolicy.UpdatedEpochMillis)
+	return diag.FromErr(d.Set(updatedEpochMillisKey, metricsPolicy.UpdatedEpochMillis))
 }
 
 func flattenPolicyRules(policy []wavefront.PolicyRule) []map[string]interface{} {
@@ -212,60 +214,68 @@ func validateAccessTypeVal(v interface{}, _ cty.Path) diag.Diagnostics {
 	return diags
 }
 
-func resourceMetricsPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	metrics := meta.(*wavefrontClient).client.MetricsPolicyAPI()
+func resourceMetricsPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
 	rawPolicy := d.Get(policyRulesKey)
 	policy, err := parsePolicyRules(rawPolicy)
 	if err != nil {
-		return err
+		return diagFromErr(err)
 	}
 	if len(policy) < 1 {
-		return fmt.Errorf("error updating Metrics Policy, no valid Policy Rules set")
+		return diag.Errorf("error updating Metrics Policy, no valid Policy Rules set")
 	}
 	newPolicyRules := &wavefront.UpdateMetricsPolicyRequest{
 		PolicyRules: policy,
 	}
 	updatedPolicy, err := metrics.Update(newPolicyRules)
 	if err != nil {
-		return fmt.Errorf("error updating metrics policy: %v", err)
+		return diag.Errorf("error updating metrics policy: %v", err)
 	}
 	d.SetId(strconv.Itoa(updatedPolicy.UpdatedEpochMillis))
 
-	return resourceMetricsPolicyRead(d, meta)
+	return resourceMetricsPolicyRead(ctx, d, meta)
 }
 
 func parsePolicyRules(raw interface{}) ([]wavefront.PolicyRuleRequest, error) {
 	var rules []wavefront.PolicyRuleRequest
 
 	rawArr := raw.([]interface{})
-	for _, r := range rawArr {
-		rule := r.(map[string]interface{})
-
-		accountIds := parseStrArr(rule[accountsKey])
-		userGroupIds := parseStrArr(rule[userGroupsKey])
-		roleIds := parseStrArr(rule[roleIdsTagKey])
-
-		if len(accountIds)+len(userGroupIds)+len(roleIds) < 1 {
-			return nil, errors.New("policy_rule must have at least one associated account, user group, or role")
-		}
-
-		newRule := wavefront.PolicyRuleRequest{
-			AccountIds:   accountIds,
-			UserGroupIds: userGroupIds,
-			RoleIds:      roleIds,
-			Name:         rule[nameKey].(string),
-			Tags:         parsePolicyTagsArr(rule[tagsKey]),
-			Description:  rule[descriptionKey].(string),
-			Prefixes:     parseStrArr(rule[prefixesKey]),
-			TagsAnded:    rule[tagsAndedKey].(bool),
-			AccessType:   rule[accessTypeKey].(string),
+	for i, r := range rawArr {
+		newRule, err := parsePolicyRule(r.(map[string]interface{}))
+		if err != nil {
+			return nil, &attributeError{
+				path: cty.GetAttrPath(policyRulesKey).IndexInt(i),
+				err:  err,
+			}
 		}
-
 		rules = append(rules, newRule)
 	}
 	return rules, nil
 }
 
+func parsePolicyRule(rule map[string]interface{}) (wavefront.PolicyRuleRequest, error) {
+	accountIds := parseStrArr(rule[accountsKey])
+	userGroupIds := parseStrArr(rule[userGroupsKey])
+	roleIds := parseStrArr(rule[roleIdsTagKey])
+
+	if len(accountIds)+len(userGroupIds)+len(roleIds) < 1 {
+		return wavefront.PolicyRuleRequest{},
+			errors.New("policy_rule must have at least one associated account, user group, or role")
+	}
+
+	return wavefront.PolicyRuleRequest{
+		AccountIds:   accountIds,
+		UserGroupIds: userGroupIds,
+		RoleIds:      roleIds,
+		Name:         rule[nameKey].(string),
+		Tags:         parsePolicyTagsArr(rule[tagsKey]),
+		Description:  rule[descriptionKey].(string),
+		Prefixes:     parseStrArr(rule[prefixesKey]),
+		TagsAnded:    rule[tagsAndedKey].(bool),
+		AccessType:   rule[accessTypeKey].(string),
+	}, nil
+}
+
 func parsePolicyTagsArr(raw interface{}) []wavefront.PolicyTag {
 	var arr []wavefront.PolicyTag
 	if raw != nil {
@@ -283,9 +293,30 @@ func parsePolicyTagsArr(raw interface{}) []wavefront.PolicyTag {
 }
 
 // resourceMetricsPolicyDelete reverts metrics policy to default predefined policy rule allowing access to all metrics for everyone
-func resourceMetricsPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceMetricsPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	defaultRule, err := defaultPolicyRule(ctx, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
+
+	defaultPolicyRules := &wavefront.UpdateMetricsPolicyRequest{
+		PolicyRules: []wavefront.PolicyRuleRequest{defaultRule},
+	}
+	_, err = metrics.Update(defaultPolicyRules)
+	if err != nil {
+		return diag.Errorf("error deleting custom metrics policy: %d", err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// defaultPolicyRule returns the predefined policy rule allowing access to all
+// metrics for everyone.
+func defaultPolicyRule(ctx context.Context, meta interface{}) (wavefront.PolicyRuleRequest, error) {
 	// needed to lookup default 'everyone' group assignment
-	groups := meta.(*wavefrontClient).client.UserGroups()
+	groups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
 	groupResults, err := groups.Find(
 		[]*wavefront.SearchCondition{
 			{
@@ -296,31 +327,21 @@ func resourceMetricsPolicyDelete(d *schema.ResourceData, meta interface{}) error
 		},
 	)
 	if err != nil {
-		return fmt.Errorf("error reading Default UserGroup 'Everyone' in Wavefront, %s", err)
+		return wavefront.PolicyRuleRequest{}, fmt.Errorf("error reading Default UserGroup 'Everyone' in Wavefront, %s", err)
 	}
 
 	if len(groupResults) != 1 {
-		return fmt.Errorf("error finding default UserGroup 'Everyone' in Wavefront")
+		return wavefront.PolicyRuleRequest{}, fmt.Errorf("error finding default UserGroup 'Everyone' in Wavefront")
 	}
 
 	defaultGroup := groupResults[0]
 
-	metrics := meta.(*wavefrontClient).client.MetricsPolicyAPI()
-
-	defaultPolicyRules := &wavefront.UpdateMetricsPolicyRequest{
-		PolicyRules: []wavefront.PolicyRuleRequest{{
-			UserGroupIds: []string{*defaultGroup.ID},
-			Name:         "Allow All Metrics",
-			Description:  "Predefined policy rule. Allows access to all metrics (timeseries, histograms, and counters) for all accounts. If this rule is removed, all accounts can access all metrics if there are no matching blocking rules.",
-			Prefixes:     []string{"*"},
-			TagsAnded:    false,
-			AccessType:   "ALLOW",
-		}},
-	}
-	_, err = metrics.Update(defaultPolicyRules)
-	if err != nil {
-		return fmt.Errorf("error deleting custom metrics policy: %d", err)
-	}
-	d.SetId("")
-	return nil
+	return wavefront.PolicyRuleRequest{
+		UserGroupIds: []string{*defaultGroup.ID},
+		Name:         "Allow All Metrics",
+		Description:  "Predefined policy rule. Allows access to all metrics (timeseries, histograms, and counters) for all accounts. If this rule is removed, all accounts can access all metrics if there are no matching blocking rules.",
+		Prefixes:     []string{"*"},
+		TagsAnded:    false,
+		AccessType:   "ALLOW",
+	}, nil
 }
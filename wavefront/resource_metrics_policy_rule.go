@@ -0,0 +1,309 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const priorityKey = "priority"
+
+// metricsPolicyMutexKey serializes the updates of the metrics policy made by
+// the provider, which replace the whole policy.
+const metricsPolicyMutexKey = "metrics_policy"
+
+// maxMetricsPolicyAttempts is the number of times a rule is written to the
+// metrics policy when someone else changes it concurrently.
+const maxMetricsPolicyAttempts = 5
+
+// resourceMetricsPolicyRule manages a single rule of the metrics policy, at a
+// given priority, leaving the other rules alone. Rules are identified by their
+// names.
+func resourceMetricsPolicyRule() *schema.Resource {
+	ruleSchema := policyRulesSchema()
+	for key, s := range ruleSchema {
+		if key != nameKey {
+			s.ForceNew = false
+		}
+	}
+	ruleSchema[priorityKey] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+	ruleSchema[updatedEpochMillisKey] = &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+
+	return &schema.Resource{
+		CreateContext: resourceMetricsPolicyRuleCreate,
+		ReadContext:   resourceMetricsPolicyRuleRead,
+		UpdateContext: resourceMetricsPolicyRuleUpdate,
+		DeleteContext: resourceMetricsPolicyRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema:   ruleSchema,
+	}
+}
+
+func resourceMetricsPolicyRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rule, err := buildMetricsPolicyRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	priority := d.Get(priorityKey).(int)
+	written := false
+	_, err = updateMetricsPolicyRules(ctx, meta, func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+		if idx := findPolicyRule(rules, rule.Name); idx >= 0 {
+			if !written {
+				return nil, fmt.Errorf("the metrics policy already has a rule named %s, import it instead", rule.Name)
+			}
+			// written by a previous attempt
+			rules = append(rules[:idx], rules[idx+1:]...)
+		}
+		written = true
+		return insertPolicyRule(rules, rule, priority), nil
+	}, policyRuleApplied(rule, priority))
+	if err != nil {
+		return diag.Errorf("error creating metrics policy rule %s. %s", rule.Name, err)
+	}
+	d.SetId(rule.Name)
+	log.Printf("[INFO] Wavefront Metrics Policy Rule %s Created", d.Id())
+
+	return resourceMetricsPolicyRuleRead(ctx, d, meta)
+}
+
+func resourceMetricsPolicyRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
+	metricsPolicy, err := metrics.Get()
+	if err != nil {
+		return diag.Errorf("error retrieving metrics policy: %s", err)
+	}
+
+	idx := -1
+	for i := range metricsPolicy.PolicyRules {
+		if metricsPolicy.PolicyRules[i].Name == d.Id() {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		log.Printf("[WARN] Wavefront Metrics Policy Rule %s not found, removing it from the state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	for key, value := range flattenPolicyRule(&metricsPolicy.PolicyRules[idx]) {
+		if err = d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set(priorityKey, idx+1); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set(updatedEpochMillisKey, metricsPolicy.UpdatedEpochMillis))
+}
+
+func resourceMetricsPolicyRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rule, err := buildMetricsPolicyRule(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	priority := d.Get(priorityKey).(int)
+	_, err = updateMetricsPolicyRules(ctx, meta, func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+		idx := findPolicyRule(rules, d.Id())
+		if idx < 0 {
+			return nil, fmt.Errorf("the metrics policy doesn't have a rule named %s anymore", d.Id())
+		}
+		rules = append(rules[:idx], rules[idx+1:]...)
+		return insertPolicyRule(rules, rule, priority), nil
+	}, policyRuleApplied(rule, priority))
+	if err != nil {
+		return diag.Errorf("error updating metrics policy rule %s. %s", d.Id(), err)
+	}
+
+	return resourceMetricsPolicyRuleRead(ctx, d, meta)
+}
+
+// resourceMetricsPolicyRuleDelete removes the rule from the metrics policy.
+// When it's the last rule, the policy reverts to the predefined rule allowing
+// access to all metrics for everyone, as when wavefront_metrics_policy is
+// deleted.
+func resourceMetricsPolicyRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_, err := updateMetricsPolicyRules(ctx, meta, func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+		idx := findPolicyRule(rules, d.Id())
+		if idx < 0 {
+			return nil, nil
+		}
+		rules = append(rules[:idx], rules[idx+1:]...)
+		if len(rules) == 0 {
+			defaultRule, err := defaultPolicyRule(ctx, meta)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, defaultRule)
+		}
+		return rules, nil
+	}, func(rules []wavefront.PolicyRuleRequest) bool {
+		return findPolicyRule(rules, d.Id()) < 0
+	})
+	if err != nil {
+		return diag.Errorf("error deleting metrics policy rule %s. %s", d.Id(), err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func buildMetricsPolicyRule(d *schema.ResourceData) (wavefront.PolicyRuleRequest, error) {
+	rule := make(map[string]interface{})
+	for key := range policyRulesSchema() {
+		rule[key] = d.Get(key)
+	}
+	return parsePolicyRule(rule)
+}
+
+// updateMetricsPolicyRules writes the rules of the metrics policy returned by
+// modify, given its current rules, to the metrics policy. A nil result leaves
+// the policy unchanged. The policy is read again right before it's written,
+// and when its updated_epoch_millis changed in between, someone else updated
+// it, so the rules are modified again from the new policy instead of
+// overwriting their change. Wavefront can't update the policy conditionally,
+// so the policy is also read again after it's written, and when applied finds
+// that someone else overwrote the change in turn, it's made again.
+func updateMetricsPolicyRules(
+	ctx context.Context,
+	meta interface{},
+	modify func([]wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error),
+	applied func([]wavefront.PolicyRuleRequest) bool,
+) (*wavefront.MetricsPolicy, error) {
+	metrics := meta.(*wavefrontClient).withContext(ctx).MetricsPolicyAPI()
+
+	wfMutexKV.Lock(metricsPolicyMutexKey)
+	defer wfMutexKV.Unlock(metricsPolicyMutexKey)
+
+	for attempt := 1; ; attempt++ {
+		if attempt > maxMetricsPolicyAttempts {
+			return nil, fmt.Errorf("the metrics policy was changed concurrently %d times, giving up", attempt-1)
+		}
+
+		policy, err := metrics.Get()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving metrics policy: %s", err)
+		}
+		rules, err := modify(policyRuleRequests(policy.PolicyRules))
+		if err != nil || rules == nil {
+			return policy, err
+		}
+
+		current, err := metrics.Get()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving metrics policy: %s", err)
+		}
+		if current.UpdatedEpochMillis != policy.UpdatedEpochMillis {
+			log.Printf("[WARN] Wavefront Metrics Policy changed concurrently, retrying")
+			continue
+		}
+		if _, err = metrics.Update(&wavefront.UpdateMetricsPolicyRequest{PolicyRules: rules}); err != nil {
+			return nil, err
+		}
+
+		written, err := metrics.Get()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving metrics policy: %s", err)
+		}
+		if applied(policyRuleRequests(written.PolicyRules)) {
+			return written, nil
+		}
+		log.Printf("[WARN] Wavefront Metrics Policy overwritten concurrently, retrying")
+	}
+}
+
+// policyRuleApplied returns whether rules have rule, with the same content,
+// at priority, as insertPolicyRule inserts it.
+func policyRuleApplied(rule wavefront.PolicyRuleRequest, priority int) func([]wavefront.PolicyRuleRequest) bool {
+	return func(rules []wavefront.PolicyRuleRequest) bool {
+		idx := findPolicyRule(rules, rule.Name)
+		if idx < 0 {
+			return false
+		}
+		if priority >= 1 && priority <= len(rules) && idx != priority-1 {
+			return false
+		}
+		return samePolicyRule(rules[idx], rule)
+	}
+}
+
+// samePolicyRule returns whether the rules are the same, regardless of the
+// order of their lists.
+func samePolicyRule(a, b wavefront.PolicyRuleRequest) bool {
+	sameStrings := func(x, y []string) bool {
+		x, y = slices.Clone(x), slices.Clone(y)
+		slices.Sort(x)
+		slices.Sort(y)
+		return slices.Equal(x, y)
+	}
+	sameTags := func(x, y []wavefront.PolicyTag) bool {
+		tags := func(t []wavefront.PolicyTag) []string {
+			result := make([]string, 0, len(t))
+			for _, tag := range t {
+				result = append(result, tag.Key+"="+tag.Value)
+			}
+			return result
+		}
+		return sameStrings(tags(x), tags(y))
+	}
+	return a.Name == b.Name && a.Description == b.Description && a.AccessType == b.AccessType &&
+		a.TagsAnded == b.TagsAnded && sameStrings(a.AccountIds, b.AccountIds) &&
+		sameStrings(a.UserGroupIds, b.UserGroupIds) && sameStrings(a.RoleIds, b.RoleIds) &&
+		sameStrings(a.Prefixes, b.Prefixes) && sameTags(a.Tags, b.Tags)
+}
+
+// policyRuleRequests converts the rules of a metrics policy to the rules of a
+// request updating it.
+func policyRuleRequests(rules []wavefront.PolicyRule) []wavefront.PolicyRuleRequest {
+	requests := make([]wavefront.PolicyRuleRequest, 0, len(rules))
+	for i := range rules {
+		requests = append(requests, wavefront.PolicyRuleRequest{
+			AccountIds:   flattenAccounts(rules[i].Accounts),
+			UserGroupIds: flattenPolicyUserGroups(rules[i].UserGroups),
+			RoleIds:      flattenPolicyRole(rules[i].Roles),
+			Name:         rules[i].Name,
+			Tags:         rules[i].Tags,
+			Description:  rules[i].Description,
+			Prefixes:     rules[i].Prefixes,
+			TagsAnded:    rules[i].TagsAnded,
+			AccessType:   rules[i].AccessType,
+		})
+	}
+	return requests
+}
+
+// findPolicyRule returns the index of the rule with the given name, or -1.
+func findPolicyRule(rules []wavefront.PolicyRuleRequest, name string) int {
+	for i := range rules {
+		if rules[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// insertPolicyRule inserts rule at priority, starting at 1. Rules without a
+// priority or with a priority past the last rule are appended.
+func insertPolicyRule(rules []wavefront.PolicyRuleRequest, rule wavefront.PolicyRuleRequest, priority int) []wavefront.PolicyRuleRequest {
+	if priority < 1 || priority > len(rules) {
+		return append(rules, rule)
+	}
+	rules = append(rules[:priority-1], append([]wavefront.PolicyRuleRequest{rule}, rules[priority-1:]...)...)
+	return rules
+}
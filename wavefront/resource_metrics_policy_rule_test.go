@@ -0,0 +1,271 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertPolicyRule(t *testing.T) {
+	rules := func(names ...string) []wavefront.PolicyRuleRequest {
+		result := make([]wavefront.PolicyRuleRequest, 0, len(names))
+		for _, name := range names {
+			result = append(result, wavefront.PolicyRuleRequest{Name: name})
+		}
+		return result
+	}
+	rule := wavefront.PolicyRuleRequest{Name: "new"}
+
+	assert.Equal(t, rules("new", "a", "b"), insertPolicyRule(rules("a", "b"), rule, 1))
+	assert.Equal(t, rules("a", "new", "b"), insertPolicyRule(rules("a", "b"), rule, 2))
+	assert.Equal(t, rules("a", "b", "new"), insertPolicyRule(rules("a", "b"), rule, 3))
+	assert.Equal(t, rules("a", "b", "new"), insertPolicyRule(rules("a", "b"), rule, 0))
+	assert.Equal(t, rules("new"), insertPolicyRule(nil, rule, 1))
+}
+
+func TestSamePolicyRule(t *testing.T) {
+	rule := wavefront.PolicyRuleRequest{
+		Name:       "rule",
+		Prefixes:   []string{"a.*", "b.*"},
+		Tags:       []wavefront.PolicyTag{{Key: "env", Value: "prod"}, {Key: "team", Value: "sre"}},
+		AccessType: "BLOCK",
+	}
+	reordered := rule
+	reordered.Prefixes = []string{"b.*", "a.*"}
+	reordered.Tags = []wavefront.PolicyTag{{Key: "team", Value: "sre"}, {Key: "env", Value: "prod"}}
+	assert.True(t, samePolicyRule(rule, reordered))
+	assert.Equal(t, []string{"a.*", "b.*"}, rule.Prefixes, "the rules must be left alone")
+
+	changed := rule
+	changed.AccessType = "ALLOW"
+	assert.False(t, samePolicyRule(rule, changed))
+	changed = rule
+	changed.Prefixes = []string{"a.*"}
+	assert.False(t, samePolicyRule(rule, changed))
+	changed = rule
+	changed.RoleIds = []string{"role"}
+	assert.False(t, samePolicyRule(rule, changed))
+}
+
+func TestMetricsPolicyRule_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	metrics := client.MetricsPolicyAPI()
+
+	group := &wavefront.UserGroup{Name: "Policy Rule Group"}
+	require.NoError(t, client.UserGroups().Create(group))
+
+	policyRule := resourceMetricsPolicyRule()
+	d := schema.TestResourceDataRaw(t, policyRule.Schema, map[string]interface{}{
+		nameKey:       "Block Secrets",
+		prefixesKey:   []interface{}{"secret.*"},
+		userGroupsKey: []interface{}{*group.ID},
+		tagsAndedKey:  false,
+		accessTypeKey: "BLOCK",
+		priorityKey:   1,
+	})
+	diags := policyRule.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Block Secrets", d.Id())
+	assert.Equal(t, 1, d.Get(priorityKey))
+
+	policy, err := metrics.Get()
+	require.NoError(t, err)
+	require.Len(t, policy.PolicyRules, 2)
+	assert.Equal(t, "Block Secrets", policy.PolicyRules[0].Name)
+	assert.Equal(t, "Allow All Metrics", policy.PolicyRules[1].Name, "the other rules must be kept")
+
+	assert.Equal(t, policy.UpdatedEpochMillis, d.Get(updatedEpochMillisKey))
+
+	// the metrics policy updated by someone else while the rule is written
+	rule, err := buildMetricsPolicyRule(d)
+	require.NoError(t, err)
+	writeRule := func(attempts *int) func([]wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+		return func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+			*attempts++
+			if idx := findPolicyRule(rules, rule.Name); idx >= 0 {
+				rules = append(rules[:idx], rules[idx+1:]...)
+			}
+			return insertPolicyRule(rules, rule, 1), nil
+		}
+	}
+	attempts := 0
+	_, err = updateMetricsPolicyRules(context.Background(), provider.Meta(),
+		func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+			if attempts == 0 {
+				concurrent := append(append([]wavefront.PolicyRuleRequest(nil), rules...), wavefront.PolicyRuleRequest{
+					Name:         "Concurrent",
+					Prefixes:     []string{"concurrent.*"},
+					UserGroupIds: []string{*group.ID},
+					AccessType:   "ALLOW",
+				})
+				_, updateErr := metrics.Update(&wavefront.UpdateMetricsPolicyRequest{PolicyRules: concurrent})
+				require.NoError(t, updateErr)
+			}
+			return writeRule(&attempts)(rules)
+		},
+		policyRuleApplied(rule, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts, "the rule must be written on top of the concurrent change")
+	policy, err = metrics.Get()
+	require.NoError(t, err)
+	require.Len(t, policy.PolicyRules, 3, "the concurrent rule must be kept")
+	assert.Equal(t, "Block Secrets", policy.PolicyRules[0].Name)
+	assert.Equal(t, "Concurrent", policy.PolicyRules[2].Name)
+
+	// the rule overwritten by someone else right after it's written
+	attempts = 0
+	_, err = updateMetricsPolicyRules(context.Background(), provider.Meta(), writeRule(&attempts),
+		func(rules []wavefront.PolicyRuleRequest) bool {
+			if attempts == 1 {
+				idx := findPolicyRule(rules, rule.Name)
+				overwritten := append(append([]wavefront.PolicyRuleRequest(nil), rules[:idx]...), rules[idx+1:]...)
+				_, updateErr := metrics.Update(&wavefront.UpdateMetricsPolicyRequest{PolicyRules: overwritten})
+				require.NoError(t, updateErr)
+				current, getErr := metrics.Get()
+				require.NoError(t, getErr)
+				rules = policyRuleRequests(current.PolicyRules)
+			}
+			return policyRuleApplied(rule, 1)(rules)
+		})
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts, "the overwritten rule must be written again")
+	policy, err = metrics.Get()
+	require.NoError(t, err)
+	require.Len(t, policy.PolicyRules, 3)
+	assert.Equal(t, "Block Secrets", policy.PolicyRules[0].Name)
+
+	// a rule which never sticks
+	_, err = updateMetricsPolicyRules(context.Background(), provider.Meta(),
+		func(rules []wavefront.PolicyRuleRequest) ([]wavefront.PolicyRuleRequest, error) {
+			return rules, nil
+		},
+		func([]wavefront.PolicyRuleRequest) bool {
+			return false
+		})
+	assert.ErrorContains(t, err, "changed concurrently 5 times")
+
+	require.NoError(t, d.Set(priorityKey, 3))
+	diags = policyRule.UpdateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	policy, err = metrics.Get()
+	require.NoError(t, err)
+	require.Len(t, policy.PolicyRules, 3)
+	assert.Equal(t, "Block Secrets", policy.PolicyRules[2].Name)
+
+	diags = policyRule.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	policy, err = metrics.Get()
+	require.NoError(t, err)
+	require.Len(t, policy.PolicyRules, 2)
+	assert.Equal(t, -1, findPolicyRule(policyRuleRequests(policy.PolicyRules), "Block Secrets"))
+
+	d.SetId("Block Secrets")
+	diags = policyRule.ReadContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, d.Id(), "a deleted rule must be planned again")
+}
+
+func TestAccWavefrontMetricsPolicyRule_Basic(t *testing.T) {
+	resourceName := "wavefront_metrics_policy_rule.rule"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontMetricsPolicyRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontMetricsPolicyRuleBasic(1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontMetricsPolicyRulePriority(resourceName, 1),
+					resource.TestCheckResourceAttr(resourceName, "name", "Block Test Metrics"),
+					resource.TestCheckResourceAttr(resourceName, "access_type", "BLOCK"),
+					resource.TestCheckResourceAttr(resourceName, "priority", "1"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontMetricsPolicyRuleBasic(2),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontMetricsPolicyRulePriority(resourceName, 2),
+					resource.TestCheckResourceAttr(resourceName, "priority", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontMetricsPolicyRulePriority(n string, priority int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		metrics := testAccProvider.Meta().(*wavefrontClient).client.MetricsPolicyAPI()
+		policy, err := metrics.Get()
+		if err != nil {
+			return fmt.Errorf("error retrieving metrics policy: %s", err)
+		}
+		idx := findPolicyRule(policyRuleRequests(policy.PolicyRules), rs.Primary.ID)
+		if idx+1 != priority {
+			return fmt.Errorf("expected metrics policy rule %s at priority %d, got %d", rs.Primary.ID, priority, idx+1)
+		}
+		return nil
+	}
+}
+
+func testAccCheckWavefrontMetricsPolicyRuleDestroy(s *terraform.State) error {
+	metrics := testAccProvider.Meta().(*wavefrontClient).client.MetricsPolicyAPI()
+	policy, err := metrics.Get()
+	if err != nil {
+		return fmt.Errorf("error retrieving metrics policy: %s", err)
+	}
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "wavefront_metrics_policy_rule" {
+			continue
+		}
+		if findPolicyRule(policyRuleRequests(policy.PolicyRules), rs.Primary.ID) >= 0 {
+			return fmt.Errorf("metrics policy rule %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckWavefrontMetricsPolicyRuleBasic(priority int) string {
+	return fmt.Sprintf(`
+resource "wavefront_user_group" "group" {
+  name        = "Metrics Policy Rule User Group"
+  description = "User Group for Metrics Policy Rule Tests"
+}
+
+resource "wavefront_metrics_policy_rule" "other" {
+  name           = "Allow Test Metrics"
+  prefixes       = ["test.allowed.*"]
+  tags_anded     = false
+  access_type    = "ALLOW"
+  user_group_ids = [wavefront_user_group.group.id]
+}
+
+resource "wavefront_metrics_policy_rule" "rule" {
+  name           = "Block Test Metrics"
+  description    = "Blocks the test metrics"
+  prefixes       = ["test.*"]
+  tags_anded     = false
+  access_type    = "BLOCK"
+  user_group_ids = [wavefront_user_group.group.id]
+  priority       = %d
+
+  depends_on = [wavefront_metrics_policy_rule.other]
+}
+`, priority)
+}
@@ -0,0 +1,316 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/cron"
+)
+
+const (
+	rmwScheduleKey  = "schedule"
+	rmwDurationKey  = "duration"
+	rmwTimezoneKey  = "timezone"
+	rmwLookaheadKey = "lookahead"
+	rmwWindowsKey   = "windows"
+	rmwIDKey        = "id"
+)
+
+func resourceRecurringMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRecurringMaintenanceWindowCreate,
+		ReadContext:   resourceRecurringMaintenanceWindowRead,
+		UpdateContext: resourceRecurringMaintenanceWindowUpdate,
+		DeleteContext: resourceRecurringMaintenanceWindowDelete,
+		Timeouts:      resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			rmwScheduleKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateCronSchedule,
+			},
+			rmwDurationKey: {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validatePositiveDuration,
+			},
+			rmwTimezoneKey: {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "UTC",
+				ValidateFunc: validateTimezone,
+			},
+			rmwLookaheadKey: {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			mwReasonKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			mwTitleKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			mwRelevantCustomerTagsKey: {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+			mwRelevantHostTagsKey: {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+			mwRelevantHostNamesKey: {
+				Type:     schema.TypeSet,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Optional: true,
+				Set:      schema.HashString,
+			},
+			mwRelevantHostTagsAndedKey: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			mwHostTagGroupHostNamesGroupAndedKey: {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			rmwWindowsKey: {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						rmwIDKey: {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						mwStartTimeInSecondsKey: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						mwEndTimeInSecondsKey: {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateCronSchedule(val interface{}, key string) (warnings []string, errors []error) {
+	if _, err := cron.Parse(val.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", key, err))
+	}
+	return warnings, errors
+}
+
+func validatePositiveDuration(val interface{}, key string) (warnings []string, errors []error) {
+	duration, err := time.ParseDuration(val.(string))
+	if err != nil || duration <= 0 {
+		errors = append(errors, fmt.Errorf("%s must be a positive duration such as 90m or 2h, got %q", key, val))
+	}
+	return warnings, errors
+}
+
+func validateTimezone(val interface{}, key string) (warnings []string, errors []error) {
+	if _, err := time.LoadLocation(val.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s must be an IANA time zone such as Europe/Paris, got %q", key, val))
+	}
+	return warnings, errors
+}
+
+// recurringWindow is a concrete maintenance window of a recurring maintenance
+// window, in seconds since the epoch.
+type recurringWindow struct {
+	start, end int64
+}
+
+// recurringWindowOccurrences returns the next count windows of the schedule
+// lasting duration, in the location loc, which haven't ended at now. It
+// returns fewer windows when the schedule runs out of matches.
+func recurringWindowOccurrences(
+	schedule *cron.Schedule, duration time.Duration, loc *time.Location, now time.Time, count int,
+) []recurringWindow {
+	occurrences := make([]recurringWindow, 0, count)
+	t := now.In(loc).Add(-duration)
+	for len(occurrences) < count {
+		t = schedule.Next(t)
+		if t.IsZero() {
+			break
+		}
+		end := t.Add(duration)
+		if end.After(now) {
+			occurrences = append(occurrences, recurringWindow{start: t.Unix(), end: end.Unix()})
+		}
+	}
+	return occurrences
+}
+
+// recurringWindowOptions returns the options of the maintenance window w of
+// the recurring maintenance window.
+func recurringWindowOptions(d *schema.ResourceData, w recurringWindow) *wavefront.MaintenanceWindowOptions {
+	return &wavefront.MaintenanceWindowOptions{
+		Reason:                          d.Get(mwReasonKey).(string),
+		Title:                           d.Get(mwTitleKey).(string),
+		StartTimeInSeconds:              w.start,
+		EndTimeInSeconds:                w.end,
+		RelevantCustomerTags:            getStringSlice(d, mwRelevantCustomerTagsKey),
+		RelevantHostTags:                getStringSlice(d, mwRelevantHostTagsKey),
+		RelevantHostNames:               getStringSlice(d, mwRelevantHostNamesKey),
+		RelevantHostTagsAnded:           d.Get(mwRelevantHostTagsAndedKey).(bool),
+		HostTagGroupHostNamesGroupAnded: d.Get(mwHostTagGroupHostNamesGroupAndedKey).(bool),
+	}
+}
+
+// sameMaintenanceWindowOptions returns whether the maintenance window mw
+// already has the options.
+func sameMaintenanceWindowOptions(mw *wavefront.MaintenanceWindow, options *wavefront.MaintenanceWindowOptions) bool {
+	sameStrings := func(a, b []string) bool {
+		a, b = slices.Clone(a), slices.Clone(b)
+		slices.Sort(a)
+		slices.Sort(b)
+		return slices.Equal(a, b)
+	}
+	return mw.Reason == options.Reason &&
+		mw.Title == options.Title &&
+		mw.StartTimeInSeconds == options.StartTimeInSeconds &&
+		mw.EndTimeInSeconds == options.EndTimeInSeconds &&
+		sameStrings(mw.RelevantCustomerTags, options.RelevantCustomerTags) &&
+		sameStrings(mw.RelevantHostTags, options.RelevantHostTags) &&
+		sameStrings(mw.RelevantHostNames, options.RelevantHostNames) &&
+		mw.RelevantHostTagsAnded == options.RelevantHostTagsAnded &&
+		mw.HostTagGroupHostNamesGroupAnded == options.HostTagGroupHostNamesGroupAnded
+}
+
+// reconcileRecurringMaintenanceWindow makes the maintenance windows in
+// Wavefront match the next windows of the schedule at now. Tracked windows
+// which ended are forgotten, those starting at an upcoming occurrence are
+// updated to its options, and the other ones are deleted unless they're in
+// progress. Missing occurrences are created.
+func reconcileRecurringMaintenanceWindow(
+	ctx context.Context, d *schema.ResourceData, meta interface{}, now time.Time,
+) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
+
+	schedule, err := cron.Parse(d.Get(rmwScheduleKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	duration, err := time.ParseDuration(d.Get(rmwDurationKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	loc, err := time.LoadLocation(d.Get(rmwTimezoneKey).(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	occurrences := recurringWindowOccurrences(schedule, duration, loc, now, d.Get(rmwLookaheadKey).(int))
+
+	var windows []map[string]interface{}
+	keep := func(mwID string, w recurringWindow) {
+		windows = append(windows, map[string]interface{}{
+			rmwIDKey:                mwID,
+			mwStartTimeInSecondsKey: int(w.start),
+			mwEndTimeInSecondsKey:   int(w.end),
+		})
+	}
+
+	matched := make(map[int64]bool, len(occurrences))
+	for _, raw := range d.Get(rmwWindowsKey).([]interface{}) {
+		mwID := raw.(map[string]interface{})[rmwIDKey].(string)
+		mw, getErr := maintenanceWindows.GetByID(mwID)
+		if wavefront.NotFound(getErr) {
+			continue
+		}
+		if getErr != nil {
+			return diag.Errorf("error finding Wavefront Maintenance Window %s. %s", mwID, getErr)
+		}
+		if mw.EndTimeInSeconds <= now.Unix() {
+			continue
+		}
+
+		i := slices.IndexFunc(occurrences, func(w recurringWindow) bool { return w.start == mw.StartTimeInSeconds })
+		if i < 0 || matched[mw.StartTimeInSeconds] {
+			if mw.StartTimeInSeconds <= now.Unix() {
+				keep(mw.ID, recurringWindow{start: mw.StartTimeInSeconds, end: mw.EndTimeInSeconds})
+				continue
+			}
+			deleteErr := maintenanceWindows.DeleteByID(mw.ID)
+			if deleteErr != nil && !wavefront.NotFound(deleteErr) {
+				return diag.Errorf("error deleting Wavefront Maintenance Window %s. %s", mw.ID, deleteErr)
+			}
+			continue
+		}
+
+		matched[mw.StartTimeInSeconds] = true
+		options := recurringWindowOptions(d, occurrences[i])
+		if !sameMaintenanceWindowOptions(mw, options) {
+			if _, updateErr := maintenanceWindows.Update(mw.ID, options); updateErr != nil {
+				return diag.Errorf("error updating Wavefront Maintenance Window %s. %s", mw.ID, updateErr)
+			}
+		}
+		keep(mw.ID, occurrences[i])
+	}
+
+	setWindows := func() error {
+		slices.SortStableFunc(windows, func(a, b map[string]interface{}) int {
+			return a[mwStartTimeInSecondsKey].(int) - b[mwStartTimeInSecondsKey].(int)
+		})
+		return d.Set(rmwWindowsKey, windows)
+	}
+	for _, w := range occurrences {
+		if matched[w.start] {
+			continue
+		}
+		mw, createErr := maintenanceWindows.Create(recurringWindowOptions(d, w))
+		if createErr != nil {
+			// track the windows created so far, to not leak them
+			diags := diag.Errorf("failed to create new Wavefront Maintenance Window, %s", createErr)
+			return append(diags, diag.FromErr(setWindows())...)
+		}
+		keep(mw.ID, w)
+	}
+	return diag.FromErr(setWindows())
+}
+
+func resourceRecurringMaintenanceWindowCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId(id.UniqueId())
+	return reconcileRecurringMaintenanceWindow(ctx, d, meta, time.Now())
+}
+
+func resourceRecurringMaintenanceWindowRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return reconcileRecurringMaintenanceWindow(ctx, d, meta, time.Now())
+}
+
+func resourceRecurringMaintenanceWindowUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return reconcileRecurringMaintenanceWindow(ctx, d, meta, time.Now())
+}
+
+func resourceRecurringMaintenanceWindowDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	maintenanceWindows := meta.(*wavefrontClient).withContext(ctx).MaintenanceWindows()
+	for _, raw := range d.Get(rmwWindowsKey).([]interface{}) {
+		mwID := raw.(map[string]interface{})[rmwIDKey].(string)
+		err := maintenanceWindows.DeleteByID(mwID)
+		if err != nil && !wavefront.NotFound(err) {
+			return diag.Errorf("error deleting Wavefront Maintenance Window %s. %s", mwID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
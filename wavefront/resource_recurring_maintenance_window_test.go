@@ -0,0 +1,190 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware/terraform-provider-wavefront/wavefront/cron"
+)
+
+func TestRecurringWindowOccurrences(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	sundays, err := cron.Parse("0 2 * * sun")
+	require.NoError(t, err)
+	window := func(start string, duration time.Duration) recurringWindow {
+		s, parseErr := time.ParseInLocation(time.DateTime, start, newYork)
+		require.NoError(t, parseErr)
+		return recurringWindow{start: s.Unix(), end: s.Add(duration).Unix()}
+	}
+
+	// Saturday 2024-03-02 noon in New York
+	now := time.Date(2024, 3, 2, 17, 0, 0, 0, time.UTC)
+	assert.Equal(t, []recurringWindow{
+		window("2024-03-03 02:00:00", 4*time.Hour),
+		window("2024-03-17 02:00:00", 4*time.Hour),
+	}, recurringWindowOccurrences(sundays, 4*time.Hour, newYork, now, 2),
+		"2am doesn't exist on 2024-03-10 in New York")
+
+	// in the middle of a window
+	now = time.Date(2024, 3, 3, 8, 0, 0, 0, time.UTC)
+	assert.Equal(t, []recurringWindow{
+		window("2024-03-03 02:00:00", 4*time.Hour),
+	}, recurringWindowOccurrences(sundays, 4*time.Hour, newYork, now, 1))
+
+	// windows longer than the period of the schedule
+	hourly, err := cron.Parse("@hourly")
+	require.NoError(t, err)
+	now = time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC)
+	assert.Equal(t, []recurringWindow{
+		{start: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC).Unix(), end: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC).Unix()},
+		{start: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC).Unix(), end: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC).Unix()},
+		{start: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC).Unix(), end: time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC).Unix()},
+	}, recurringWindowOccurrences(hourly, 2*time.Hour, time.UTC, now, 3))
+
+	never, err := cron.Parse("0 0 30 feb *")
+	require.NoError(t, err)
+	assert.Empty(t, recurringWindowOccurrences(never, time.Hour, time.UTC, now, 3))
+}
+
+func TestRecurringMaintenanceWindow_FakeAPI(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+	ctx := context.Background()
+
+	recurring := resourceRecurringMaintenanceWindow()
+	d := schema.TestResourceDataRaw(t, recurring.Schema, map[string]interface{}{
+		rmwScheduleKey:        "0 2 * * sun",
+		rmwDurationKey:        "4h",
+		rmwLookaheadKey:       3,
+		mwReasonKey:           "Patching",
+		mwTitleKey:            "Sunday Patching",
+		mwRelevantHostTagsKey: []interface{}{"env.prod"},
+	})
+	d.SetId("recurring")
+	windowIDs := func() []string {
+		var ids []string
+		for _, w := range d.Get(rmwWindowsKey).([]interface{}) {
+			ids = append(ids, w.(map[string]interface{})[rmwIDKey].(string))
+		}
+		return ids
+	}
+
+	// Saturday 2024-03-02
+	now := time.Date(2024, 3, 2, 12, 0, 0, 0, time.UTC)
+	diags := reconcileRecurringMaintenanceWindow(ctx, d, provider.Meta(), now)
+	require.False(t, diags.HasError(), "%v", diags)
+	ids := windowIDs()
+	require.Len(t, ids, 3)
+	first, err := client.MaintenanceWindows().GetByID(ids[0])
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 3, 3, 2, 0, 0, 0, time.UTC).Unix(), first.StartTimeInSeconds)
+	assert.Equal(t, time.Date(2024, 3, 3, 6, 0, 0, 0, time.UTC).Unix(), first.EndTimeInSeconds)
+	assert.Equal(t, []string{"env.prod"}, first.RelevantHostTags)
+
+	// a week later, in the middle of the first window, one more window is
+	// created and the tracked ones are kept
+	now = time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC)
+	diags = reconcileRecurringMaintenanceWindow(ctx, d, provider.Meta(), now)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, ids[1:], windowIDs()[:2])
+	assert.Len(t, windowIDs(), 3)
+
+	// the scoping of the windows is updated, and the windows which don't
+	// match the new schedule are deleted unless in progress
+	ids = windowIDs()
+	require.NoError(t, d.Set(rmwScheduleKey, "0 2 * * sat"))
+	require.NoError(t, d.Set(mwRelevantHostTagsKey, []string{"env.staging"}))
+	diags = reconcileRecurringMaintenanceWindow(ctx, d, provider.Meta(), now)
+	require.False(t, diags.HasError(), "%v", diags)
+	updated := windowIDs()
+	require.Len(t, updated, 4)
+	assert.Equal(t, ids[0], updated[0], "the window in progress must be kept")
+	for _, removed := range ids[1:] {
+		_, err = client.MaintenanceWindows().GetByID(removed)
+		assert.Error(t, err, "the upcoming Sunday windows must be deleted")
+	}
+	saturday, err := client.MaintenanceWindows().GetByID(updated[1])
+	require.NoError(t, err)
+	assert.Equal(t, time.Saturday, time.Unix(saturday.StartTimeInSeconds, 0).UTC().Weekday())
+	assert.Equal(t, []string{"env.staging"}, saturday.RelevantHostTags)
+
+	// windows deleted outside of Terraform are recreated
+	require.NoError(t, client.MaintenanceWindows().DeleteByID(updated[1]))
+	diags = reconcileRecurringMaintenanceWindow(ctx, d, provider.Meta(), now)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, windowIDs(), 4)
+	assert.NotContains(t, windowIDs(), updated[1])
+
+	ids = windowIDs()
+	diags = recurring.DeleteContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	for _, deleted := range ids {
+		_, err = client.MaintenanceWindows().GetByID(deleted)
+		assert.Error(t, err)
+	}
+}
+
+func TestAccWavefrontRecurringMaintenanceWindow_Basic(t *testing.T) {
+	resourceName := "wavefront_recurring_maintenance_window.patching"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontRecurringMaintenanceWindowDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontRecurringMaintenanceWindow(3),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "windows.#", "3"),
+					resource.TestCheckResourceAttrSet(resourceName, "windows.0.id"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontRecurringMaintenanceWindow(5),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "windows.#", "5"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontRecurringMaintenanceWindowDestroy(s *terraform.State) error {
+	maintenanceWindows := testAccProvider.Meta().(*wavefrontClient).client.MaintenanceWindows()
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "wavefront_recurring_maintenance_window" {
+			continue
+		}
+		for key, mwID := range rs.Primary.Attributes {
+			var i int
+			if _, err := fmt.Sscanf(key, "windows.%d.id", &i); err != nil {
+				continue
+			}
+			if _, err := maintenanceWindows.GetByID(mwID); err == nil {
+				return fmt.Errorf("maintenance window still exists, %s", mwID)
+			}
+		}
+	}
+	return nil
+}
+
+func testAccCheckWavefrontRecurringMaintenanceWindow(lookahead int) string {
+	return fmt.Sprintf(`
+resource "wavefront_recurring_maintenance_window" "patching" {
+  reason             = "Weekly patching"
+  title              = "Terraform Test Recurring Maintenance Window"
+  schedule           = "0 2 * * sun"
+  duration           = "4h"
+  timezone           = "Europe/Paris"
+  lookahead          = %d
+  relevant_host_tags = ["env.prod"]
+}
+`, lookahead)
+}
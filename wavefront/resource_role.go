@@ -1,23 +1,26 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceRoleCreate,
-		Read:   resourceRoleRead,
-		Update: resourceRoleUpdate,
-		Delete: resourceRoleDelete,
+		CreateContext: resourceRoleCreate,
+		ReadContext:   resourceRoleRead,
+		UpdateContext: resourceRoleUpdate,
+		DeleteContext: resourceRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -62,8 +65,8 @@ func getChangedLists(d *schema.ResourceData, key string) ([]string, []string) {
 	return oldP, newP
 }
 
-func resourceRoleCreate(d *schema.ResourceData, meta interface{}) error {
-	r := meta.(*wavefrontClient).client.Roles()
+func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	r := meta.(*wavefrontClient).withContext(ctx).Roles()
 
 	_, permissions := getPermissions(d)
 	_, assignees := getAssignees(d)
@@ -76,22 +79,22 @@ func resourceRoleCreate(d *schema.ResourceData, meta interface{}) error {
 
 	err := r.Create(role)
 	if err != nil {
-		return fmt.Errorf("error trying to create role %s. %s", role.Name, err)
+		return diag.Errorf("error trying to create role %s. %s", role.Name, err)
 	}
 	d.SetId(role.ID)
 
 	if len(assignees) > 0 {
 		err = r.AddAssignees(assignees, role)
 		if err != nil {
-			return fmt.Errorf("error trying to add assignees %v on role %s. %s", assignees, role.ID, err)
+			return diag.Errorf("error trying to add assignees %v on role %s. %s", assignees, role.ID, err)
 		}
 	}
 
-	return resourceRoleRead(d, meta)
+	return resourceRoleRead(ctx, d, meta)
 }
 
-func resourceRoleRead(d *schema.ResourceData, meta interface{}) error {
-	r := meta.(*wavefrontClient).client.Roles()
+func resourceRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	r := meta.(*wavefrontClient).withContext(ctx).Roles()
 	roles, err := r.Find([]*wavefront.SearchCondition{
 		{
 			Key:            "id",
@@ -104,7 +107,7 @@ func resourceRoleRead(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(roles) == 0 {
@@ -123,8 +126,12 @@ func resourceRoleRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
-	r := meta.(*wavefrontClient).client.Roles()
+func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	r := meta.(*wavefrontClient).withContext(ctx).Roles()
+
+	// serialize the updates of the role with those of its role assignments
+	wfMutexKV.Lock(d.Id())
+	defer wfMutexKV.Unlock(d.Id())
 
 	_, np := getPermissions(d)
 	oa, na := getAssignees(d)
@@ -154,13 +161,13 @@ func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	err := r.Update(role)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(na) > 0 {
 		err = r.AddAssignees(na, role)
 		if err != nil {
-			return fmt.Errorf("error trying to add assignees %v on role %s. %s", na, role.ID, err)
+			return diag.Errorf("error trying to add assignees %v on role %s. %s", na, role.ID, err)
 		}
 	}
 
@@ -169,8 +176,8 @@ func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			// Endpoint will swallow errors if some are bad and others are not, but otherwise will throw an error
 			// when all assignees to remove are bad...
-			if !strings.Contains(err.Error(), "No valid user or user group IDs were found") {
-				return fmt.Errorf("error trying to remove assignees %v on role %s. %s", removeAssignees, role.ID, err)
+			if !strings.Contains(err.Error(), noValidAssigneesError) {
+				return diag.Errorf("error trying to remove assignees %v on role %s. %s", removeAssignees, role.ID, err)
 			}
 		}
 	}
@@ -178,15 +185,15 @@ func resourceRoleUpdate(d *schema.ResourceData, meta interface{}) error {
 	for _, p := range np {
 		err = r.GrantPermission(p, []*wavefront.Role{role})
 		if err != nil {
-			return fmt.Errorf("error trying to grant permission %s on role %s. %s", p, role.ID, err)
+			return diag.Errorf("error trying to grant permission %s on role %s. %s", p, role.ID, err)
 		}
 	}
 
-	return resourceRoleRead(d, meta)
+	return resourceRoleRead(ctx, d, meta)
 }
 
-func resourceRoleDelete(d *schema.ResourceData, meta interface{}) error {
-	r := meta.(*wavefrontClient).client.Roles()
+func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	r := meta.(*wavefrontClient).withContext(ctx).Roles()
 	roles, err := r.Find([]*wavefront.SearchCondition{
 		{
 			Key:            "id",
@@ -199,7 +206,7 @@ func resourceRoleDelete(d *schema.ResourceData, meta interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(roles) == 0 {
@@ -210,8 +217,8 @@ func resourceRoleDelete(d *schema.ResourceData, meta interface{}) error {
 	role := roles[0]
 	err = r.Delete(role)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceRoleRead(d, meta)
+	return resourceRoleRead(ctx, d, meta)
 }
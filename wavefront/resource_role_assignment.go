@@ -0,0 +1,200 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	roleIDKey     = "role_id"
+	assigneeIDKey = "assignee_id"
+)
+
+// noValidAssigneesError is the error Wavefront returns when none of the
+// assignees added to or removed from a role exist.
+const noValidAssigneesError = "No valid user or user group IDs were found"
+
+// resourceRoleAssignment assigns a role to a single user, service account or
+// user group, leaving the other assignees of the role alone.
+func resourceRoleAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRoleAssignmentCreate,
+		ReadContext:   resourceRoleAssignmentRead,
+		DeleteContext: resourceRoleAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceRoleAssignmentImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			roleIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			assigneeIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func roleAssignmentID(roleID, assigneeID string) string {
+	return fmt.Sprintf("%s/%s", roleID, assigneeID)
+}
+
+// parseRoleAssignmentID splits the ID of a role assignment. Role IDs are
+// UUIDs, so the assignee ID is everything after the first slash.
+func parseRoleAssignmentID(id string) (roleID, assigneeID string, err error) {
+	idx := strings.Index(id, "/")
+	if idx <= 0 || idx == len(id)-1 {
+		return "", "", fmt.Errorf("invalid role assignment ID %q, expected <role_id>/<assignee_id>", id)
+	}
+	return id[:idx], id[idx+1:], nil
+}
+
+func resourceRoleAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roles := meta.(*wavefrontClient).withContext(ctx).Roles()
+	roleID := d.Get(roleIDKey).(string)
+	assigneeID := d.Get(assigneeIDKey).(string)
+
+	wfMutexKV.Lock(roleID)
+	defer wfMutexKV.Unlock(roleID)
+
+	err := roles.AddAssignees([]string{assigneeID}, &wavefront.Role{ID: roleID})
+	if err != nil {
+		return diag.Errorf("error trying to add assignee %s on role %s. %s", assigneeID, roleID, err)
+	}
+	d.SetId(roleAssignmentID(roleID, assigneeID))
+	log.Printf("[INFO] Assigned Wavefront Role %s to %s", roleID, assigneeID)
+
+	return resourceRoleAssignmentRead(ctx, d, meta)
+}
+
+// resourceRoleAssignmentRead removes the assignment from the state when the
+// role, the assignee or the assignment itself doesn't exist anymore, so that
+// it's assigned again.
+func resourceRoleAssignmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*wavefrontClient).withContext(ctx)
+	roleID, assigneeID, err := parseRoleAssignmentID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = client.Roles().Get(&wavefront.Role{ID: roleID})
+	if err == nil {
+		var assigned []string
+		assigned, err = assigneeRoleIDs(client, assigneeID)
+		if err == nil && !slices.Contains(assigned, roleID) {
+			log.Printf("[WARN] Wavefront Role %s is not assigned to %s anymore", roleID, assigneeID)
+			d.SetId("")
+			return nil
+		}
+	}
+	if err != nil {
+		if wavefront.NotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("error finding Wavefront Role assignment %s. %s", d.Id(), err)
+	}
+
+	if err = d.Set(roleIDKey, roleID); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set(assigneeIDKey, assigneeID))
+}
+
+func resourceRoleAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	roles := meta.(*wavefrontClient).withContext(ctx).Roles()
+	roleID := d.Get(roleIDKey).(string)
+	assigneeID := d.Get(assigneeIDKey).(string)
+
+	wfMutexKV.Lock(roleID)
+	defer wfMutexKV.Unlock(roleID)
+
+	err := roles.RemoveAssignees([]string{assigneeID}, &wavefront.Role{ID: roleID})
+	if err != nil && !wavefront.NotFound(err) && !strings.Contains(err.Error(), noValidAssigneesError) {
+		return diag.Errorf("error trying to remove assignee %s on role %s. %s", assigneeID, roleID, err)
+	}
+	d.SetId("")
+	return nil
+}
+
+func resourceRoleAssignmentImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	roleID, assigneeID, err := parseRoleAssignmentID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err = d.Set(roleIDKey, roleID); err != nil {
+		return nil, err
+	}
+	if err = d.Set(assigneeIDKey, assigneeID); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// assigneeRoleIDs returns the IDs of the roles assigned to the user, service
+// account or user group with the given ID. Service account IDs start with
+// sa::, user IDs are email addresses, and user group IDs are UUIDs.
+func assigneeRoleIDs(client *wavefront.Client, assigneeID string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(assigneeID, "sa::"):
+		serviceAccount, err := client.ServiceAccounts().GetByID(assigneeID)
+		if err != nil {
+			return nil, err
+		}
+		return serviceAccount.RoleIds(), nil
+	case strings.Contains(assigneeID, "@"):
+		return userRoleIDs(client, assigneeID)
+	default:
+		group := &wavefront.UserGroup{ID: &assigneeID}
+		if err := client.UserGroups().Get(group); err != nil {
+			return nil, err
+		}
+		ids := make([]string, 0, len(group.Roles))
+		for _, role := range group.Roles {
+			ids = append(ids, role.ID)
+		}
+		return ids, nil
+	}
+}
+
+// userRoleIDs returns the IDs of the roles assigned to the user with the
+// given ID. wavefront.User doesn't have the roles of users, so the user is
+// decoded here.
+func userRoleIDs(client *wavefront.Client, userID string) ([]string, error) {
+	req, err := client.NewRequest(http.MethodGet, "user/"+url.PathEscape(userID), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	body, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var user struct {
+		Roles []wavefront.Role `json:"roles"`
+	}
+	if err = json.NewDecoder(body).Decode(&user); err != nil {
+		return nil, fmt.Errorf("error parsing Wavefront User %s, %s", userID, err)
+	}
+	ids := make([]string, 0, len(user.Roles))
+	for _, role := range user.Roles {
+		ids = append(ids, role.ID)
+	}
+	return ids, nil
+}
@@ -0,0 +1,146 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRoleAssignmentID(t *testing.T) {
+	roleID, assigneeID, err := parseRoleAssignmentID("0a1b2c3d-role/sa::deployer")
+	require.NoError(t, err)
+	assert.Equal(t, "0a1b2c3d-role", roleID)
+	assert.Equal(t, "sa::deployer", assigneeID)
+
+	for _, id := range []string{"", "0a1b2c3d-role", "/jane@example.com", "0a1b2c3d-role/"} {
+		_, _, err = parseRoleAssignmentID(id)
+		assert.ErrorContains(t, err, "expected <role_id>/<assignee_id>", id)
+	}
+}
+
+func TestRoleAssignment_Assignees(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+
+	role := &wavefront.Role{Name: "Assigned Role"}
+	require.NoError(t, client.Roles().Create(role))
+	group := &wavefront.UserGroup{Name: "Assigned Group"}
+	require.NoError(t, client.UserGroups().Create(group))
+	require.NoError(t, client.Users().Create(&wavefront.NewUserRequest{EmailAddress: "assigned@example.com"},
+		&wavefront.User{}, false))
+	_, err := client.ServiceAccounts().Create(&wavefront.ServiceAccountOptions{ID: "sa::assigned", Active: true})
+	require.NoError(t, err)
+
+	assignment := resourceRoleAssignment()
+	for _, assigneeID := range []string{*group.ID, "assigned@example.com", "sa::assigned"} {
+		d := schema.TestResourceDataRaw(t, assignment.Schema, map[string]interface{}{
+			roleIDKey:     role.ID,
+			assigneeIDKey: assigneeID,
+		})
+		diags := assignment.CreateContext(context.Background(), d, provider.Meta())
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, role.ID+"/"+assigneeID, d.Id())
+
+		roleIDs, assignedErr := assigneeRoleIDs(&client, assigneeID)
+		require.NoError(t, assignedErr)
+		assert.Contains(t, roleIDs, role.ID, assigneeID)
+
+		// the assignment removed outside Terraform
+		require.NoError(t, client.Roles().RemoveAssignees([]string{assigneeID}, role))
+		diags = assignment.ReadContext(context.Background(), d, provider.Meta())
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Empty(t, d.Id(), "the assignment to %s must be planned again", assigneeID)
+	}
+
+	d := schema.TestResourceDataRaw(t, assignment.Schema, map[string]interface{}{
+		roleIDKey:     role.ID,
+		assigneeIDKey: "sa::assigned",
+	})
+	diags := assignment.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	diags = assignment.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	roleIDs, err := assigneeRoleIDs(&client, "sa::assigned")
+	require.NoError(t, err)
+	assert.NotContains(t, roleIDs, role.ID)
+}
+
+func TestAccWavefrontRoleAssignment_Basic(t *testing.T) {
+	resourceName := "wavefront_role_assignment.group"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontRoleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontRoleAssignmentBasic(),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontRoleAssigned(resourceName),
+					testAccCheckWavefrontRoleAssigned("wavefront_role_assignment.user"),
+					resource.TestCheckResourceAttrPair(resourceName, "role_id", "wavefront_role.role", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "assignee_id", "wavefront_user_group.group", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontRoleAssigned(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		client := testAccProvider.Meta().(*wavefrontClient).client
+		roleIDs, err := assigneeRoleIDs(&client, rs.Primary.Attributes["assignee_id"])
+		if err != nil {
+			return fmt.Errorf("error finding Wavefront Role assignment %s", err)
+		}
+		for _, id := range roleIDs {
+			if id == rs.Primary.Attributes["role_id"] {
+				return nil
+			}
+		}
+		return fmt.Errorf("role %s is not assigned to %s", rs.Primary.Attributes["role_id"],
+			rs.Primary.Attributes["assignee_id"])
+	}
+}
+
+func testAccCheckWavefrontRoleAssignmentBasic() string {
+	return `
+resource "wavefront_role" "role" {
+  name = "Test Role Assignment"
+}
+
+resource "wavefront_user_group" "group" {
+  name        = "Role Assignment User Group"
+  description = "User Group for Role Assignment Tests"
+}
+
+resource "wavefront_user" "user" {
+  email = "role-assignment+tftesting@example.com"
+}
+
+resource "wavefront_role_assignment" "group" {
+  role_id     = wavefront_role.role.id
+  assignee_id = wavefront_user_group.group.id
+}
+
+resource "wavefront_role_assignment" "user" {
+  role_id     = wavefront_role.role.id
+  assignee_id = wavefront_user.user.id
+}
+`
+}
@@ -1,21 +1,23 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceServiceAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceServiceAccountCreate,
-		Read:   resourceServiceAccountRead,
-		Update: resourceServiceAccountUpdate,
-		Delete: resourceServiceAccountDelete,
+		CreateContext: resourceServiceAccountCreate,
+		ReadContext:   resourceServiceAccountRead,
+		UpdateContext: resourceServiceAccountUpdate,
+		DeleteContext: resourceServiceAccountDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"identifier": {
 				Type:     schema.TypeString,
@@ -53,9 +55,9 @@ func resourceServiceAccount() *schema.Resource {
 	}
 }
 
-func resourceServiceAccountCreate(d *schema.ResourceData, meta interface{}) error {
-	serviceAccounts := meta.(*wavefrontClient).client.ServiceAccounts()
-	tokens := meta.(*wavefrontClient).client.Tokens()
+func resourceServiceAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
+	tokens := meta.(*wavefrontClient).withContext(ctx).Tokens()
 
 	serviceAccount, err := serviceAccounts.Create(
 		&wavefront.ServiceAccountOptions{
@@ -67,63 +69,64 @@ func resourceServiceAccountCreate(d *schema.ResourceData, meta interface{}) erro
 			IngestionPolicyID: d.Get("ingestion_policy").(string),
 		})
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"failed to create new Wavefront Service Account, %s",
 			err)
 	}
 	_, err = tokens.Create(serviceAccount.ID, &wavefront.TokenOptions{Name: "main"})
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"failed to create token for new Wavefront ServiceAccount, %s",
 			err)
 	}
 	d.SetId(serviceAccount.ID)
 
-	return resourceServiceAccountRead(d, meta)
+	return resourceServiceAccountRead(ctx, d, meta)
 }
 
-func resourceServiceAccountRead(d *schema.ResourceData, meta interface{}) error {
-	serviceAccounts := meta.(*wavefrontClient).client.ServiceAccounts()
+func resourceServiceAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
 	serviceAccount, err := serviceAccounts.GetByID(d.Id())
 	if wavefront.NotFound(err) {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error finding Wavefront Service Account %s. %s",
 			d.Id(),
 			err)
 	}
-	if err := d.Set("identifier", serviceAccount.ID); err != nil {
-		return err
+	for key, value := range flattenServiceAccount(serviceAccount) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
 	}
-	if err := d.Set("active", serviceAccount.Active); err != nil {
-		return err
-	}
-	if err := d.Set("description", serviceAccount.Description); err != nil {
-		return err
-	}
-	if err := d.Set("ingestion_policy", serviceAccount.IngestionPolicyId()); err != nil {
-		return err
-	}
-	err = setStringSlice(d, "permissions", serviceAccount.Permissions)
-	if err != nil {
-		return err
+	return nil
+}
+
+// flattenServiceAccount returns the attributes of the service account,
+// shared by the resource and the data sources.
+func flattenServiceAccount(serviceAccount *wavefront.ServiceAccount) map[string]interface{} {
+	return map[string]interface{}{
+		"identifier":       serviceAccount.ID,
+		"active":           serviceAccount.Active,
+		"description":      serviceAccount.Description,
+		"ingestion_policy": serviceAccount.IngestionPolicyId(),
+		"permissions":      serviceAccount.Permissions,
+		"user_groups":      serviceAccount.UserGroupIds(),
 	}
-	return setStringSlice(d, "user_groups", serviceAccount.UserGroupIds())
 }
 
-func resourceServiceAccountUpdate(
-	d *schema.ResourceData, meta interface{}) error {
-	serviceAccounts := meta.(*wavefrontClient).client.ServiceAccounts()
+func resourceServiceAccountUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
 	serviceAccount, err := serviceAccounts.GetByID(d.Id())
 	if wavefront.NotFound(err) {
 		d.SetId("")
 		return nil
 	}
 	if err != nil {
-		return fmt.Errorf(""+
+		return diag.Errorf(""+
 			"error finding Wavefront Service Account %s. %s",
 			d.Id(),
 			err)
@@ -146,22 +149,21 @@ func resourceServiceAccountUpdate(
 	}
 	_, err = serviceAccounts.Update(options)
 	if err != nil {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error updating Wavefront Service Account  %s. %s",
 			d.Id(),
 			err,
 		)
 	}
 
-	return resourceServiceAccountRead(d, meta)
+	return resourceServiceAccountRead(ctx, d, meta)
 }
 
-func resourceServiceAccountDelete(
-	d *schema.ResourceData, meta interface{}) error {
-	serviceAccounts := meta.(*wavefrontClient).client.ServiceAccounts()
+func resourceServiceAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
 	err := serviceAccounts.DeleteByID(d.Id())
 	if err != nil && !wavefront.NotFound(err) {
-		return fmt.Errorf(
+		return diag.Errorf(
 			"error deleting Wavefront Service Account %s. %s",
 			d.Id(),
 			err,
@@ -0,0 +1,193 @@
+package wavefront
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	serviceAccountIDKey = "service_account_id"
+	rotateTriggersKey   = "rotate_triggers"
+	tokenKey            = "token"
+)
+
+// The Wavefront token ID is the credential itself, so it never becomes the
+// Terraform ID where it would be printed in plan and apply output. Instead,
+// the ID is the service account ID and a digest of the token.
+const tokenDigestLength = 16
+
+func resourceServiceAccountToken() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceAccountTokenCreate,
+		ReadContext:   resourceServiceAccountTokenRead,
+		UpdateContext: resourceServiceAccountTokenUpdate,
+		DeleteContext: resourceServiceAccountTokenDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceServiceAccountTokenImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			serviceAccountIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			nameKey: {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			rotateTriggersKey: {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			tokenKey: {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func serviceAccountTokenID(serviceAccountID, token string) string {
+	digest := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("%s/%s", serviceAccountID, hex.EncodeToString(digest[:])[:tokenDigestLength])
+}
+
+func parseServiceAccountTokenID(id string) (serviceAccountID, rest string, err error) {
+	idx := strings.LastIndex(id, "/")
+	if idx <= 0 || idx == len(id)-1 {
+		return "", "", fmt.Errorf("invalid service account token ID %q, expected <service_account_id>/<token>", id)
+	}
+	return id[:idx], id[idx+1:], nil
+}
+
+// findServiceAccountToken returns the token of the service account whose ID is
+// token, or nil if the service account doesn't have it anymore.
+func findServiceAccountToken(serviceAccount *wavefront.ServiceAccount, token string) *wavefront.Token {
+	for i := range serviceAccount.Tokens {
+		if serviceAccount.Tokens[i].ID == token {
+			return &serviceAccount.Tokens[i]
+		}
+	}
+	return nil
+}
+
+func resourceServiceAccountTokenCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
+	tokens := meta.(*wavefrontClient).withContext(ctx).Tokens()
+	serviceAccountID := d.Get(serviceAccountIDKey).(string)
+
+	// The API answers a create with every token of the service account, so
+	// the new one is told apart from those that existed before. Serialize on
+	// the service account so that concurrent creates don't confuse each other.
+	wfMutexKV.Lock(serviceAccountID)
+	defer wfMutexKV.Unlock(serviceAccountID)
+
+	serviceAccount, err := serviceAccounts.GetByID(serviceAccountID)
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Service Account %s. %s", serviceAccountID, err)
+	}
+	existing := make(map[string]bool, len(serviceAccount.Tokens))
+	for _, id := range serviceAccount.TokenIds() {
+		existing[id] = true
+	}
+
+	allTokens, err := tokens.Create(serviceAccountID, &wavefront.TokenOptions{Name: d.Get(nameKey).(string)})
+	if err != nil {
+		return diag.Errorf("failed to create token for Wavefront Service Account %s, %s", serviceAccountID, err)
+	}
+	var created *wavefront.Token
+	for i := range allTokens {
+		if !existing[allTokens[i].ID] {
+			created = &allTokens[i]
+			break
+		}
+	}
+	if created == nil {
+		return diag.Errorf("failed to find the new token of Wavefront Service Account %s", serviceAccountID)
+	}
+
+	d.SetId(serviceAccountTokenID(serviceAccountID, created.ID))
+	if err := d.Set(tokenKey, created.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceServiceAccountTokenRead(ctx, d, meta)
+}
+
+func resourceServiceAccountTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	serviceAccounts := meta.(*wavefrontClient).withContext(ctx).ServiceAccounts()
+	serviceAccountID, _, err := parseServiceAccountTokenID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	serviceAccount, err := serviceAccounts.GetByID(serviceAccountID)
+	if wavefront.NotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error finding Wavefront Service Account %s. %s", serviceAccountID, err)
+	}
+	token := findServiceAccountToken(serviceAccount, d.Get(tokenKey).(string))
+	if token == nil {
+		d.SetId("")
+		return nil
+	}
+	if err := d.Set(serviceAccountIDKey, serviceAccount.ID); err != nil {
+		return diag.FromErr(err)
+	}
+	return diag.FromErr(d.Set(nameKey, token.Name))
+}
+
+func resourceServiceAccountTokenUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tokens := meta.(*wavefrontClient).withContext(ctx).Tokens()
+	serviceAccountID := d.Get(serviceAccountIDKey).(string)
+	if d.HasChange(nameKey) {
+		_, err := tokens.Update(serviceAccountID, &wavefront.TokenOptions{
+			ID:   d.Get(tokenKey).(string),
+			Name: d.Get(nameKey).(string),
+		})
+		if err != nil {
+			return diag.Errorf("error updating token %s of Wavefront Service Account %s. %s", d.Id(), serviceAccountID, err)
+		}
+	}
+	return resourceServiceAccountTokenRead(ctx, d, meta)
+}
+
+func resourceServiceAccountTokenDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	tokens := meta.(*wavefrontClient).withContext(ctx).Tokens()
+	serviceAccountID := d.Get(serviceAccountIDKey).(string)
+	err := tokens.Delete(serviceAccountID, d.Get(tokenKey).(string))
+	if err != nil && !wavefront.NotFound(err) {
+		return diag.Errorf("error deleting token %s of Wavefront Service Account %s. %s", d.Id(), serviceAccountID, err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceServiceAccountTokenImport accepts <service_account_id>/<token>,
+// the only way to name an existing token, and replaces it with the digest ID.
+func resourceServiceAccountTokenImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	serviceAccountID, token, err := parseServiceAccountTokenID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set(serviceAccountIDKey, serviceAccountID); err != nil {
+		return nil, err
+	}
+	if err := d.Set(tokenKey, token); err != nil {
+		return nil, err
+	}
+	d.SetId(serviceAccountTokenID(serviceAccountID, token))
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,174 @@
+package wavefront
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceAccountTokenID(t *testing.T) {
+	id := serviceAccountTokenID("sa::tftesting", "c0ffee00-0000-4000-8000-000000000000")
+	assert.Equal(t, "sa::tftesting/", id[:len("sa::tftesting/")])
+	assert.NotContains(t, id, "c0ffee00")
+	assert.Len(t, id, len("sa::tftesting/")+tokenDigestLength)
+
+	serviceAccountID, digest, err := parseServiceAccountTokenID(id)
+	assert.NoError(t, err)
+	assert.Equal(t, "sa::tftesting", serviceAccountID)
+	assert.Len(t, digest, tokenDigestLength)
+
+	for _, invalid := range []string{"", "sa::tftesting", "sa::tftesting/", "/token"} {
+		_, _, err = parseServiceAccountTokenID(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestFindServiceAccountToken(t *testing.T) {
+	serviceAccount := &wavefront.ServiceAccount{
+		Tokens: []wavefront.Token{
+			{ID: "token-1", Name: "main"},
+			{ID: "token-2", Name: "proxy"},
+		},
+	}
+	assert.Equal(t, "proxy", findServiceAccountToken(serviceAccount, "token-2").Name)
+	assert.Nil(t, findServiceAccountToken(serviceAccount, "token-3"))
+}
+
+func TestAccWavefrontServiceAccountToken_Basic(t *testing.T) {
+	var token wavefront.Token
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontServiceAccountTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWavefrontServiceAccountTokenBasic("proxy", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontServiceAccountTokenExists("wavefront_service_account_token.proxy", &token),
+					resource.TestCheckResourceAttr(
+						"wavefront_service_account_token.proxy", "name", "proxy"),
+					resource.TestCheckResourceAttr(
+						"wavefront_service_account_token.proxy", "service_account_id", "sa::tftesting-token"),
+					resource.TestCheckResourceAttrSet(
+						"wavefront_service_account_token.proxy", "token"),
+				),
+			},
+			{
+				Config: testAccWavefrontServiceAccountTokenBasic("proxy-renamed", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontServiceAccountTokenExists("wavefront_service_account_token.proxy", &token),
+					resource.TestCheckResourceAttr(
+						"wavefront_service_account_token.proxy", "name", "proxy-renamed"),
+				),
+			},
+			{
+				ResourceName:      "wavefront_service_account_token.proxy",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["wavefront_service_account_token.proxy"]
+					return fmt.Sprintf("%s/%s",
+						rs.Primary.Attributes["service_account_id"], rs.Primary.Attributes["token"]), nil
+				},
+				ImportStateVerifyIgnore: []string{"rotate_triggers"},
+			},
+		},
+	})
+}
+
+func TestAccWavefrontServiceAccountToken_Rotate(t *testing.T) {
+	var before, after wavefront.Token
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontServiceAccountTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWavefrontServiceAccountTokenBasic("proxy", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontServiceAccountTokenExists("wavefront_service_account_token.proxy", &before),
+				),
+			},
+			{
+				Config: testAccWavefrontServiceAccountTokenBasic("proxy", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontServiceAccountTokenExists("wavefront_service_account_token.proxy", &after),
+					func(s *terraform.State) error {
+						if before.ID == after.ID {
+							return fmt.Errorf("token was not rotated")
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckWavefrontServiceAccountTokenDestroy(s *terraform.State) error {
+	serviceAccounts := testAccProvider.Meta().(*wavefrontClient).client.ServiceAccounts()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "wavefront_service_account_token" {
+			continue
+		}
+		serviceAccount, err := serviceAccounts.GetByID(rs.Primary.Attributes["service_account_id"])
+		if wavefront.NotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error finding Wavefront Service Account, %s", err)
+		}
+		if findServiceAccountToken(serviceAccount, rs.Primary.Attributes["token"]) != nil {
+			return fmt.Errorf("token still exists, %s", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccCheckWavefrontServiceAccountTokenExists(
+	n string, token *wavefront.Token) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no Record ID is set")
+		}
+
+		serviceAccounts := testAccProvider.Meta().(*wavefrontClient).client.ServiceAccounts()
+		serviceAccount, err := serviceAccounts.GetByID(rs.Primary.Attributes["service_account_id"])
+		if err != nil {
+			return fmt.Errorf("error finding Wavefront Service Account %s", err)
+		}
+		result := findServiceAccountToken(serviceAccount, rs.Primary.Attributes["token"])
+		if result == nil {
+			return fmt.Errorf("token not found %s", rs.Primary.ID)
+		}
+		*token = *result
+		return nil
+	}
+}
+
+func testAccWavefrontServiceAccountTokenBasic(name, rotation string) string {
+	return fmt.Sprintf(`
+resource "wavefront_service_account" "basic" {
+	identifier = "sa::tftesting-token"
+	active     = true
+}
+
+resource "wavefront_service_account_token" "proxy" {
+	service_account_id = wavefront_service_account.basic.id
+	name               = "%s"
+	rotate_triggers = {
+		rotation = "%s"
+	}
+}`, name, rotation)
+}
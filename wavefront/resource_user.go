@@ -1,22 +1,25 @@
 package wavefront
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceUser() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserCreate,
-		Read:   resourceUserRead,
-		Update: resourceUserUpdate,
-		Delete: resourceUserDelete,
+		CreateContext: resourceUserCreate,
+		ReadContext:   resourceUserRead,
+		UpdateContext: resourceUserUpdate,
+		DeleteContext: resourceUserDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"email": {
 				Type:     schema.TypeString,
@@ -46,8 +49,8 @@ func resourceUser() *schema.Resource {
 	}
 }
 
-func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
-	users := meta.(*wavefrontClient).client.Users()
+func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	users := meta.(*wavefrontClient).withContext(ctx).Users()
 
 	newUserRequest := &wavefront.NewUserRequest{
 		EmailAddress: d.Get("email").(string),
@@ -55,26 +58,26 @@ func resourceUserCreate(d *schema.ResourceData, meta interface{}) error {
 
 	err := resourceDecodeUserPermissions(d, newUserRequest)
 	if err != nil {
-		return fmt.Errorf("error extracting permissions from terraform state. %s", err)
+		return diag.Errorf("error extracting permissions from terraform state. %s", err)
 	}
 
 	err = decodeUserGroups(d, newUserRequest)
 	if err != nil {
-		return fmt.Errorf("error extracting user groups from terraform state. %s", err)
+		return diag.Errorf("error extracting user groups from terraform state. %s", err)
 	}
 
 	user := &wavefront.User{}
 	if err := users.Create(newUserRequest, user, true); err != nil {
-		return fmt.Errorf("failed to create new user, %s", err)
+		return diag.Errorf("failed to create new user, %s", err)
 	}
 
 	d.SetId(*user.ID)
 
-	return resourceUserRead(d, meta)
+	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
-	users := meta.(*wavefrontClient).client.Users()
+func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	users := meta.(*wavefrontClient).withContext(ctx).Users()
 
 	results, err := users.Find(
 		[]*wavefront.SearchCondition{
@@ -85,7 +88,7 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
 	}
 
 	if len(results) == 0 {
@@ -111,8 +114,8 @@ func resourceUserRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
-	users := meta.(*wavefrontClient).client.Users()
+func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	users := meta.(*wavefrontClient).withContext(ctx).Users()
 	results, err := users.Find(
 		[]*wavefront.SearchCondition{
 			{
@@ -122,7 +125,7 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
 	}
 
 	if len(results) == 0 {
@@ -136,23 +139,23 @@ func resourceUserUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	err = resourceDecodeUserPermissions(d, u)
 	if err != nil {
-		return fmt.Errorf("error decoding permissions from state into the user %s. %s", d.Id(), err)
+		return diag.Errorf("error decoding permissions from state into the user %s. %s", d.Id(), err)
 	}
 	err = decodeUserGroups(d, u)
 	if err != nil {
-		return fmt.Errorf("error decoding user groups from state into the user %s. %s", d.Id(), err)
+		return diag.Errorf("error decoding user groups from state into the user %s. %s", d.Id(), err)
 	}
 
 	err = users.Update(u)
 	if err != nil {
-		return fmt.Errorf("error updating Wavefront User %s. %s", d.Id(), err)
+		return diag.Errorf("error updating Wavefront User %s. %s", d.Id(), err)
 	}
 
-	return resourceUserRead(d, meta)
+	return resourceUserRead(ctx, d, meta)
 }
 
-func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
-	users := meta.(*wavefrontClient).client.Users()
+func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	users := meta.(*wavefrontClient).withContext(ctx).Users()
 	results, err := users.Find(
 		[]*wavefront.SearchCondition{
 			{
@@ -162,14 +165,14 @@ func resourceUserDelete(d *schema.ResourceData, meta interface{}) error {
 			},
 		})
 	if err != nil {
-		return fmt.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
+		return diag.Errorf("error finding Wavefront User %s. %s", d.Id(), err)
 	}
 
 	// Delete the user
 	u := results[0]
 	err = users.Delete(u)
 	if err != nil {
-		return fmt.Errorf("error deleting Wavefront User %s. %s", d.Id(), err)
+		return diag.Errorf("error deleting Wavefront User %s. %s", d.Id(), err)
 	}
 
 	d.SetId("")
@@ -1,21 +1,23 @@
 package wavefront
 
 import (
-	"fmt"
+	"context"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceUserGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceUserGroupCreate,
-		Read:   resourceUserGroupRead,
-		Update: resourceUserGroupUpdate,
-		Delete: resourceUserGroupDelete,
+		CreateContext: resourceUserGroupCreate,
+		ReadContext:   resourceUserGroupRead,
+		UpdateContext: resourceUserGroupUpdate,
+		DeleteContext: resourceUserGroupDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: resourceTimeouts(),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -29,8 +31,8 @@ func resourceUserGroup() *schema.Resource {
 	}
 }
 
-func resourceUserGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	userGroups := meta.(*wavefrontClient).client.UserGroups()
+func resourceUserGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
 
 	ug := &wavefront.UserGroup{
 		Name:        d.Get("name").(string),
@@ -38,23 +40,23 @@ func resourceUserGroupCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err := userGroups.Create(ug); err != nil {
-		return fmt.Errorf("failed to create user group, %s", err)
+		return diag.Errorf("failed to create user group, %s", err)
 	}
 
 	d.SetId(*ug.ID)
 
-	return resourceUserGroupRead(d, meta)
+	return resourceUserGroupRead(ctx, d, meta)
 }
 
-func resourceUserGroupRead(d *schema.ResourceData, meta interface{}) error {
-	userGroups := meta.(*wavefrontClient).client.UserGroups()
+func resourceUserGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
 	id := d.Id()
 	ug := &wavefront.UserGroup{
 		ID: &id,
 	}
 
 	if err := userGroups.Get(ug); err != nil {
-		return fmt.Errorf("unable to find user group %s, %s", id, err)
+		return diag.Errorf("unable to find user group %s, %s", id, err)
 	}
 
 	d.Set("name", ug.Name)
@@ -63,8 +65,8 @@ func resourceUserGroupRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceUserGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	userGroups := meta.(*wavefrontClient).client.UserGroups()
+func resourceUserGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
 
 	id := d.Id()
 	ug := &wavefront.UserGroup{
@@ -75,14 +77,14 @@ func resourceUserGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	ug.Description = d.Get("description").(string)
 
 	if err := userGroups.Update(ug); err != nil {
-		return fmt.Errorf("unable to update user group %s, %s", id, err)
+		return diag.Errorf("unable to update user group %s, %s", id, err)
 	}
 
-	return resourceUserGroupRead(d, meta)
+	return resourceUserGroupRead(ctx, d, meta)
 }
 
-func resourceUserGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	userGroups := meta.(*wavefrontClient).client.UserGroups()
+func resourceUserGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
 
 	id := d.Id()
 	ug := &wavefront.UserGroup{
@@ -90,7 +92,7 @@ func resourceUserGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if err := userGroups.Delete(ug); err != nil {
-		return fmt.Errorf("unable to delete user group %s, %s", id, err)
+		return diag.Errorf("unable to delete user group %s, %s", id, err)
 	}
 
 	d.SetId("")
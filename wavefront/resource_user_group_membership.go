@@ -0,0 +1,169 @@
+package wavefront
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceUserGroupMembership adds users to a user group without owning its
+// members: the members it doesn't list are left alone, so several
+// memberships, users and service accounts can add members to one group.
+func resourceUserGroupMembership() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUserGroupMembershipCreate,
+		ReadContext:   resourceUserGroupMembershipRead,
+		UpdateContext: resourceUserGroupMembershipUpdate,
+		DeleteContext: resourceUserGroupMembershipDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceUserGroupMembershipImport,
+		},
+		Timeouts: resourceTimeouts(),
+		Schema: map[string]*schema.Schema{
+			userGroupIDKey: {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			usersKey: {
+				Type:     schema.TypeSet,
+				Required: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceUserGroupMembershipCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
+	groupID := d.Get(userGroupIDKey).(string)
+	users := getStringSlice(d, usersKey)
+
+	if err := userGroups.AddUsers(&groupID, &users); err != nil {
+		return diag.Errorf("failed to add users %v to user group %s, %s", users, groupID, err)
+	}
+	d.SetId(userGroupMembershipID(groupID, users))
+	log.Printf("[INFO] Added users %v to Wavefront User Group %s", users, groupID)
+
+	return resourceUserGroupMembershipRead(ctx, d, meta)
+}
+
+// resourceUserGroupMembershipRead only keeps the users of the membership
+// which are still members of the group, so the users removed from it outside
+// Terraform are added again, while the members added outside are ignored.
+func resourceUserGroupMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	groupID := d.Get(userGroupIDKey).(string)
+	members, err := userGroupMembers(ctx, groupID, meta)
+	if err != nil {
+		if wavefront.NotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("unable to find user group %s, %s", groupID, err)
+	}
+
+	var users []string
+	for _, user := range getStringSlice(d, usersKey) {
+		if members[user] {
+			users = append(users, user)
+		}
+	}
+	if err = setStringSlice(d, usersKey, users); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceUserGroupMembershipUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
+	groupID := d.Get(userGroupIDKey).(string)
+	oldUsers, newUsers := d.GetChange(usersKey)
+	removed, added := compareStringSliceAnyOrder(
+		parseStrArr(oldUsers.(*schema.Set).List()), parseStrArr(newUsers.(*schema.Set).List()))
+
+	if len(added) > 0 {
+		if err := userGroups.AddUsers(&groupID, &added); err != nil {
+			return diag.Errorf("failed to add users %v to user group %s, %s", added, groupID, err)
+		}
+	}
+	if len(removed) > 0 {
+		if err := userGroups.RemoveUsers(&groupID, &removed); err != nil {
+			return diag.Errorf("failed to remove users %v from user group %s, %s", removed, groupID, err)
+		}
+	}
+
+	return resourceUserGroupMembershipRead(ctx, d, meta)
+}
+
+func resourceUserGroupMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
+	groupID := d.Get(userGroupIDKey).(string)
+	users := getStringSlice(d, usersKey)
+
+	if len(users) > 0 {
+		err := userGroups.RemoveUsers(&groupID, &users)
+		if err != nil && !wavefront.NotFound(err) {
+			return diag.Errorf("failed to remove users %v from user group %s, %s", users, groupID, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceUserGroupMembershipImport imports the users listed in an ID of the
+// form <user_group_id>/<user_id>[,<user_id>...], and only them, as the other
+// members of the group may be managed elsewhere.
+func resourceUserGroupMembershipImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	groupID, list, _ := strings.Cut(d.Id(), "/")
+	var users []string
+	for _, user := range strings.Split(list, ",") {
+		if user = strings.TrimSpace(user); user != "" {
+			users = append(users, user)
+		}
+	}
+	if groupID == "" || len(users) == 0 {
+		return nil, fmt.Errorf(
+			"invalid user group membership ID %q, expected <user_group_id>/<user_id>[,<user_id>...]", d.Id())
+	}
+	if err := d.Set(userGroupIDKey, groupID); err != nil {
+		return nil, err
+	}
+	if err := setStringSlice(d, usersKey, users); err != nil {
+		return nil, err
+	}
+	d.SetId(userGroupMembershipID(groupID, users))
+	return []*schema.ResourceData{d}, nil
+}
+
+// userGroupMembershipID returns the ID of the membership of users to the
+// user group, so that several memberships of a group have distinct IDs. The
+// ID is kept when the users of the membership change.
+func userGroupMembershipID(groupID string, users []string) string {
+	sorted := append([]string(nil), users...)
+	sort.Strings(sorted)
+	digest := sha256.Sum256([]byte(strings.Join(sorted, ",")))
+	return fmt.Sprintf("%s/%s", groupID, hex.EncodeToString(digest[:])[:tokenDigestLength])
+}
+
+// userGroupMembers returns the IDs of the users and service accounts which
+// are members of the user group with the given ID.
+func userGroupMembers(ctx context.Context, id string, meta interface{}) (map[string]bool, error) {
+	userGroups := meta.(*wavefrontClient).withContext(ctx).UserGroups()
+	group := &wavefront.UserGroup{ID: &id}
+	if err := userGroups.Get(group); err != nil {
+		return nil, err
+	}
+	members := make(map[string]bool, len(group.Users))
+	for _, user := range group.Users {
+		members[user] = true
+	}
+	return members, nil
+}
@@ -0,0 +1,167 @@
+package wavefront
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserGroupMembership_ReadToleratesDrift(t *testing.T) {
+	provider := fakeAPIProvider(t)
+	client := provider.Meta().(*wavefrontClient).client
+
+	group := &wavefront.UserGroup{Name: "Membership Drift"}
+	require.NoError(t, client.UserGroups().Create(group))
+	for _, email := range []string{"drift-a@example.com", "drift-b@example.com", "drift-c@example.com"} {
+		require.NoError(t, client.Users().Create(&wavefront.NewUserRequest{EmailAddress: email}, &wavefront.User{}, false))
+	}
+
+	membership := resourceUserGroupMembership()
+	d := schema.TestResourceDataRaw(t, membership.Schema, map[string]interface{}{
+		userGroupIDKey: *group.ID,
+		usersKey:       []interface{}{"drift-a@example.com", "drift-b@example.com"},
+	})
+	diags := membership.CreateContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, strings.HasPrefix(d.Id(), *group.ID+"/"))
+
+	// members added and removed outside Terraform
+	require.NoError(t, client.UserGroups().AddUsers(group.ID, &[]string{"drift-c@example.com"}))
+	require.NoError(t, client.UserGroups().RemoveUsers(group.ID, &[]string{"drift-b@example.com"}))
+	diags = membership.ReadContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.ElementsMatch(t, []string{"drift-a@example.com"}, getStringSlice(d, usersKey))
+
+	imported := membership.TestResourceData()
+	imported.SetId(*group.ID + "/drift-b@example.com,drift-a@example.com")
+	states, err := membership.Importer.StateContext(context.Background(), imported, provider.Meta())
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, d.Id(), states[0].Id(), "the imported membership must have the ID of the created one")
+	assert.Equal(t, *group.ID, states[0].Get(userGroupIDKey))
+	assert.ElementsMatch(t, []string{"drift-a@example.com", "drift-b@example.com"}, getStringSlice(states[0], usersKey),
+		"only the users listed must be imported")
+
+	invalid := membership.TestResourceData()
+	invalid.SetId(*group.ID)
+	_, err = membership.Importer.StateContext(context.Background(), invalid, provider.Meta())
+	assert.Error(t, err, "the users must be listed")
+
+	other := schema.TestResourceDataRaw(t, membership.Schema, map[string]interface{}{
+		userGroupIDKey: *group.ID,
+		usersKey:       []interface{}{"drift-c@example.com"},
+	})
+	diags = membership.CreateContext(context.Background(), other, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.NotEqual(t, d.Id(), other.Id(), "memberships of a group must have distinct IDs")
+
+	diags = membership.DeleteContext(context.Background(), d, provider.Meta())
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NoError(t, client.UserGroups().Get(group))
+	assert.Equal(t, []string{"drift-c@example.com"}, group.Users, "members added outside must be kept")
+}
+
+func TestAccWavefrontUserGroupMembership_Basic(t *testing.T) {
+	resourceName := "wavefront_user_group_membership.members"
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckWavefrontUserGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckWavefrontUserGroupMembership(`wavefront_user.first.id`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontUserGroupMembers("wavefront_user_group.group", 1),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "user_group_id", "wavefront_user_group.group", "id"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateIdFunc: testAccUserGroupMembershipImportID(resourceName),
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccCheckWavefrontUserGroupMembership(`wavefront_user.first.id, wavefront_user.second.id`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontUserGroupMembers("wavefront_user_group.group", 2),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "2"),
+				),
+			},
+			{
+				Config: testAccCheckWavefrontUserGroupMembership(`wavefront_user.second.id`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWavefrontUserGroupMembers("wavefront_user_group.group", 1),
+					resource.TestCheckResourceAttr(resourceName, "users.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+// testAccUserGroupMembershipImportID returns the import ID of the membership,
+// <user_group_id>/<user_id>[,<user_id>...].
+func testAccUserGroupMembershipImportID(n string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return "", fmt.Errorf("not found: %s", n)
+		}
+		var users []string
+		for key, value := range rs.Primary.Attributes {
+			if strings.HasPrefix(key, "users.") && key != "users.#" {
+				users = append(users, value)
+			}
+		}
+		return rs.Primary.Attributes["user_group_id"] + "/" + strings.Join(users, ","), nil
+	}
+}
+
+func testAccCheckWavefrontUserGroupMembers(n string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		userGroups := testAccProvider.Meta().(*wavefrontClient).client.UserGroups()
+		group := &wavefront.UserGroup{ID: &rs.Primary.ID}
+		if err := userGroups.Get(group); err != nil {
+			return fmt.Errorf("error finding Wavefront User Group %s", err)
+		}
+		if len(group.Users) != count {
+			return fmt.Errorf("expected %d members, got %v", count, group.Users)
+		}
+		return nil
+	}
+}
+
+func testAccCheckWavefrontUserGroupMembership(users string) string {
+	return fmt.Sprintf(`
+resource "wavefront_user_group" "group" {
+  name        = "Membership User Group"
+  description = "User Group for Membership Tests"
+}
+
+resource "wavefront_user" "first" {
+  email = "membership-first+tftesting@example.com"
+}
+
+resource "wavefront_user" "second" {
+  email = "membership-second+tftesting@example.com"
+}
+
+resource "wavefront_user_group_membership" "members" {
+  user_group_id = wavefront_user_group.group.id
+  users         = [%s]
+}
+`, users)
+}
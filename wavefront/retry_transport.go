@@ -0,0 +1,153 @@
+package wavefront
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchPathPrefix is the path of the search API, after the path of the
+// address. Searches are sent with POST but don't modify anything, so they're
+// as safe to retry as a GET.
+const searchPathPrefix = "/api/v2/search/"
+
+// retryTransport retries requests that were throttled with a 429 or that
+// failed with a 5xx or a network error, waiting an exponentially growing
+// delay between attempts. Requests that failed after reaching the server are
+// only retried if repeating them can't apply a change twice.
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryTransport(base http.RoundTripper, maxRetries int, minBackoff, maxBackoff time.Duration) *retryTransport {
+	return &retryTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		minBackoff: minBackoff,
+		maxBackoff: maxBackoff,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := t.base.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !t.shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				wait = min(retryAfter, t.maxBackoff)
+			}
+			log.Printf("[DEBUG] %s %s returned %s, retrying in %s (%d/%d)",
+				req.Method, req.URL.Path, resp.Status, wait, attempt+1, t.maxRetries)
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		} else {
+			log.Printf("[DEBUG] %s %s failed: %s, retrying in %s (%d/%d)",
+				req.Method, req.URL.Path, err, wait, attempt+1, t.maxRetries)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req)
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		// A throttled request wasn't processed, whatever its method.
+		return true
+	case resp.StatusCode >= 500 && resp.StatusCode != http.StatusNotImplemented:
+		return isIdempotent(req)
+	}
+	return false
+}
+
+// isIdempotent returns whether sending req more than once has the same effect
+// as sending it once.
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return strings.Contains(req.URL.Path, searchPathPrefix)
+	}
+	return false
+}
+
+// backoff returns how long to wait before the retry following attempt. It
+// doubles with each attempt, between minBackoff and maxBackoff, with jitter
+// so that concurrent operations don't retry in lockstep.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	wait := t.maxBackoff
+	if attempt < 32 {
+		if exp := t.minBackoff << uint(attempt); exp > 0 && exp < t.maxBackoff {
+			wait = exp
+		}
+	}
+	if wait <= 0 {
+		return 0
+	}
+	// Use between half and all of the computed delay.
+	half := wait / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either
+// a number of seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+	wait := date.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
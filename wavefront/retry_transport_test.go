@@ -0,0 +1,224 @@
+package wavefront
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRetryingClient returns a Wavefront client for server that retries like
+// the provider does, without waiting long between attempts.
+func testRetryingClient(t *testing.T, server *httptest.Server, maxRetries int) *wavefront.Client {
+	return testRetryingClientWithMaxBackoff(t, server, maxRetries, 5*time.Millisecond)
+}
+
+// testRetryingClientWithMaxBackoff returns a Wavefront client for server that
+// waits at most maxBackoff between attempts.
+func testRetryingClientWithMaxBackoff(
+	t *testing.T, server *httptest.Server, maxRetries int, maxBackoff time.Duration,
+) *wavefront.Client {
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	httpClient := getHTTPClient(client)
+	httpClient.Transport = newRetryTransport(httpClient.Transport, maxRetries, time.Millisecond, maxBackoff)
+	return client
+}
+
+// failingHandler answers the first failures requests with status, then
+// answers like next.
+func failingHandler(failures int32, status int, header http.Header, next http.HandlerFunc) (http.HandlerFunc, *int32) {
+	var calls int32
+	return func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		next(w, r)
+	}, &calls
+}
+
+func alertHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = io.WriteString(w, `{"status":{"code":200},"response":{"id":"1234","name":"Test Alert"}}`)
+}
+
+func TestRetryTransport_RetriesThrottledRequests(t *testing.T) {
+	handler, calls := failingHandler(2, http.StatusTooManyRequests, nil, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	id := "1234"
+	alert := &wavefront.Alert{ID: &id}
+	err := testRetryingClient(t, server, 3).Alerts().Get(alert)
+	require.NoError(t, err)
+	assert.Equal(t, "Test Alert", alert.Name)
+	assert.EqualValues(t, 3, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_RetriesThrottledCreates(t *testing.T) {
+	var bodies []string
+	handler, calls := failingHandler(1, http.StatusTooManyRequests, nil, alertHandler)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		handler(w, r)
+	}))
+	defer server.Close()
+
+	err := testRetryingClient(t, server, 3).Alerts().Create(&wavefront.Alert{Name: "Test Alert"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, atomic.LoadInt32(calls))
+	require.Len(t, bodies, 2)
+	assert.Equal(t, bodies[0], bodies[1], "the body must be sent again")
+	assert.Contains(t, bodies[1], "Test Alert")
+}
+
+func TestRetryTransport_DoesNotRetryFailedCreates(t *testing.T) {
+	handler, calls := failingHandler(1, http.StatusServiceUnavailable, nil, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	err := testRetryingClient(t, server, 3).Alerts().Create(&wavefront.Alert{Name: "Test Alert"})
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_RetriesFailedSearches(t *testing.T) {
+	handler, calls := failingHandler(1, http.StatusBadGateway, nil, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"status":{"code":200},"response":{"moreItems":false,"items":[{"id":"1234"}]}}`)
+	})
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	alerts, err := testRetryingClient(t, server, 3).Alerts().Find(nil)
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+	assert.EqualValues(t, 2, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_GivesUpAfterMaxRetries(t *testing.T) {
+	handler, calls := failingHandler(10, http.StatusInternalServerError, nil, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	id := "1234"
+	err := testRetryingClient(t, server, 2).Alerts().Get(&wavefront.Alert{ID: &id})
+	assert.Error(t, err)
+	assert.EqualValues(t, 3, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	handler, calls := failingHandler(1, http.StatusBadRequest, nil, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	id := "1234"
+	err := testRetryingClient(t, server, 3).Alerts().Get(&wavefront.Alert{ID: &id})
+	assert.Error(t, err)
+	assert.EqualValues(t, 1, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_HonoursRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"1"}}
+	handler, calls := failingHandler(1, http.StatusTooManyRequests, header, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	id := "1234"
+	start := time.Now()
+	err := testRetryingClientWithMaxBackoff(t, server, 3, 2*time.Second).Alerts().Get(&wavefront.Alert{ID: &id})
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), time.Second)
+	assert.EqualValues(t, 2, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_CapsRetryAfter(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"60"}}
+	handler, calls := failingHandler(1, http.StatusTooManyRequests, header, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	id := "1234"
+	start := time.Now()
+	err := testRetryingClient(t, server, 3).Alerts().Get(&wavefront.Alert{ID: &id})
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), time.Second, "the wait must be capped at max_backoff")
+	assert.EqualValues(t, 2, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_StopsWhenCancelled(t *testing.T) {
+	header := http.Header{"Retry-After": []string{"60"}}
+	handler, calls := failingHandler(1, http.StatusTooManyRequests, header, alertHandler)
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	id := "1234"
+	client := testRetryingClientWithMaxBackoff(t, server, 3, time.Minute)
+	err := clientWithContext(ctx, client).Alerts().Get(&wavefront.Alert{ID: &id})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.EqualValues(t, 1, atomic.LoadInt32(calls))
+}
+
+func TestRetryTransport_Backoff(t *testing.T) {
+	transport := newRetryTransport(http.DefaultTransport, 10, time.Second, 10*time.Second)
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		wait := transport.backoff(attempt)
+		assert.GreaterOrEqual(t, wait, expected/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, wait, expected, "attempt %d", attempt)
+	}
+	assert.LessOrEqual(t, transport.backoff(100), 10*time.Second)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 11, 10, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"", 0, false},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Fri, 10 Nov 2023 12:00:30 GMT", 30 * time.Second, true},
+		{"Fri, 10 Nov 2023 11:59:00 GMT", 0, true},
+	}
+	for _, c := range cases {
+		wait, ok := parseRetryAfter(c.value, now)
+		assert.Equal(t, c.ok, ok, c.value)
+		assert.Equal(t, c.expected, wait, c.value)
+	}
+}
+
+func TestIsIdempotent(t *testing.T) {
+	cases := []struct {
+		method   string
+		path     string
+		expected bool
+	}{
+		{http.MethodGet, "/api/v2/alert/1234", true},
+		{http.MethodPut, "/api/v2/alert/1234", true},
+		{http.MethodDelete, "/api/v2/alert/1234", true},
+		{http.MethodPost, "/api/v2/alert", false},
+		{http.MethodPost, "/api/v2/search/alert", true},
+		{http.MethodPost, "/wavefront/api/v2/search/alert", true},
+		{http.MethodPatch, "/api/v2/alert/1234", false},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, nil)
+		assert.Equal(t, c.expected, isIdempotent(req), "%s %s", c.method, c.path)
+	}
+}
@@ -0,0 +1,101 @@
+package wavefront
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// tlsOptions are the TLS settings of the provider, for Wavefront endpoints
+// using a private CA or requiring client certificates.
+type tlsOptions struct {
+	CACertFile         string
+	CACertPEM          string
+	ClientCert         string
+	ClientKey          string
+	InsecureSkipVerify bool
+}
+
+// config returns the TLS configuration of the options, or nil if they keep
+// the default one.
+func (o tlsOptions) config() (*tls.Config, error) {
+	if o == (tlsOptions{}) {
+		return nil, nil
+	}
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: o.InsecureSkipVerify,
+	}
+
+	if o.CACertFile != "" || o.CACertPEM != "" {
+		// Trust the CA in addition to the system ones, which VMware Cloud
+		// Services certificates are signed by.
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if o.CACertFile != "" {
+			caPEM, readErr := os.ReadFile(o.CACertFile)
+			if readErr != nil {
+				return nil, fmt.Errorf("failed to read ca_cert_file, %s", readErr)
+			}
+			if !pool.AppendCertsFromPEM(caPEM) {
+				return nil, fmt.Errorf("ca_cert_file %s contains no PEM encoded certificate", o.CACertFile)
+			}
+		}
+		if o.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(o.CACertPEM)) {
+			return nil, fmt.Errorf("ca_cert_pem contains no PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+
+	if (o.ClientCert == "") != (o.ClientKey == "") {
+		return nil, fmt.Errorf("client_cert and client_key must be set together")
+	}
+	if o.ClientCert != "" {
+		certPEM, err := pemOrFile("client_cert", o.ClientCert)
+		if err != nil {
+			return nil, err
+		}
+		keyPEM, err := pemOrFile("client_key", o.ClientKey)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate, %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// pemOrFile returns value if it's PEM encoded, otherwise the content of the
+// file it's the path of.
+func pemOrFile(key, value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	content, err := os.ReadFile(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s, %s", key, err)
+	}
+	return content, nil
+}
+
+// applyTLSConfig configures the transport of the Wavefront client's
+// httpClient, before it is wrapped by the provider's transports.
+func applyTLSConfig(httpClient *http.Client, config *tls.Config) error {
+	if config == nil {
+		return nil
+	}
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected Wavefront client transport %T", httpClient.Transport)
+	}
+	transport.TLSClientConfig = config
+	return nil
+}
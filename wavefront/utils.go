@@ -1,10 +1,14 @@
 package wavefront
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
 
 	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const (
@@ -28,6 +32,7 @@ const (
 	updatedEpochMillisKey                 = "updated_epoch_millis"
 	userGroupsKey                         = "user_group_ids"
 	userGroupsListKey                     = "user_groups"
+	userGroupIDKey                        = "user_group_id"
 	usersKey                              = "users"
 	queryKey                              = "query"
 	minutesKey                            = "minutes"
@@ -63,6 +68,11 @@ const (
 	earliestStartTimeEpochMillis          = "earliest_start_time_epoch_millis"
 	limitKey                              = "limit"
 	offsetKey                             = "offset"
+	filterKey                             = "filter"
+	filterKeyKey                          = "key"
+	filterValueKey                        = "value"
+	matchingMethodKey                     = "matching_method"
+	negatedKey                            = "negated"
 	dashboardsKey                         = "dashboards"
 	constantsKey                          = "constants"
 	parametersKey                         = "parameters"
@@ -173,22 +183,159 @@ func parseStrArr(raw interface{}) []string {
 	return arr
 }
 
-func searchAll(limit int, offset int, typ string, timeRange *wavefront.TimeRange, filter []*wavefront.SearchCondition, m interface{}) json.RawMessage {
-	searchParams := &wavefront.SearchParams{
-		Conditions: filter,
-		Limit:      limit,
-		Offset:     offset,
-		TimeRange:  timeRange,
+// searchPageSize is the largest page the search API returns.
+const searchPageSize = 1000
+
+// The matching methods of the search API.
+var searchMatchingMethods = []string{"CONTAINS", "STARTSWITH", "EXACT", "TAGPATH"}
+
+// searchCondition is a search API condition. Unlike wavefront.SearchCondition
+// it can be negated.
+type searchCondition struct {
+	Key            string `json:"key"`
+	Value          string `json:"value"`
+	MatchingMethod string `json:"matchingMethod"`
+	Negated        bool   `json:"negated"`
+}
+
+type searchRequest struct {
+	Conditions []*searchCondition   `json:"query"`
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+	TimeRange  *wavefront.TimeRange `json:"timeRange,omitempty"`
+}
+
+type searchResponse struct {
+	Response struct {
+		Items     []json.RawMessage `json:"items"`
+		MoreItems bool              `json:"moreItems"`
+	} `json:"response"`
+}
+
+// searchFilterSchema is the schema of the filter blocks of the plural data
+// sources, which are sent to the search API as conditions.
+func searchFilterSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				filterKeyKey: {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+				filterValueKey: {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				matchingMethodKey: {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "CONTAINS",
+					ValidateFunc: validation.StringInSlice(searchMatchingMethods, false),
+				},
+				negatedKey: {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+			},
+		},
+	}
+}
+
+// expandSearchFilters returns the search conditions of the filter blocks.
+func expandSearchFilters(d *schema.ResourceData) []*searchCondition {
+	var conditions []*searchCondition
+	for _, raw := range d.Get(filterKey).([]interface{}) {
+		filter := raw.(map[string]interface{})
+		conditions = append(conditions, &searchCondition{
+			Key:            filter[filterKeyKey].(string),
+			Value:          filter[filterValueKey].(string),
+			MatchingMethod: filter[matchingMethodKey].(string),
+			Negated:        filter[negatedKey].(bool),
+		})
+	}
+	return conditions
+}
+
+// searchAll returns the items of type typ matching filter, starting at offset.
+// It follows moreItems until it has limit items or there are no more. A limit
+// of zero returns every matching item.
+func searchAll(ctx context.Context, limit int, offset int, typ string, timeRange *wavefront.TimeRange, filter []*searchCondition, m interface{}) (json.RawMessage, error) {
+	client := m.(*wavefrontClient).withContext(ctx)
+	items := make([]json.RawMessage, 0)
+	for {
+		pageSize := searchPageSize
+		if remaining := limit - len(items); limit > 0 && remaining < pageSize {
+			pageSize = remaining
+		}
+		page, moreItems, err := searchPage(client, typ, &searchRequest{
+			Conditions: filter,
+			Limit:      pageSize,
+			Offset:     offset,
+			TimeRange:  timeRange,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+
+		// Stop on an empty page too, so that a server claiming to have more
+		// items without returning any can't keep us looping.
+		if !moreItems || len(page) == 0 || (limit > 0 && len(items) >= limit) {
+			break
+		}
+		offset += len(page)
 	}
+	return json.Marshal(items)
+}
 
-	searchClient := m.(*wavefrontClient).client.NewSearch(typ, searchParams)
-	var searchResponse *wavefront.SearchResponse
-	var err error
-	searchResponse, err = searchClient.Execute()
+// searchOne returns the item of type typ whose field key is value. It fails
+// unless exactly one item matches, so that names can be looked up.
+func searchOne(ctx context.Context, typ, key, value string, m interface{}) (json.RawMessage, error) {
+	filter := []*searchCondition{{Key: key, Value: value, MatchingMethod: "EXACT"}}
+	items, err := searchAll(ctx, 2, 0, typ, nil, filter, m)
+	if err != nil {
+		return nil, err
+	}
+	var matches []json.RawMessage
+	if err = json.Unmarshal(items, &matches); err != nil {
+		return nil, err
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no %s with %s %q found", typ, key, value)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, fmt.Errorf("more than one %s with %s %q found", typ, key, value)
+	}
+}
+
+// searchPath is the path of the search API relative to the base URL of the
+// client, so that a path in the address of the provider is kept.
+const searchPath = "search/"
 
+func searchPage(client wavefront.Wavefronter, typ string, search *searchRequest) ([]json.RawMessage, bool, error) {
+	payload, err := json.Marshal(search)
+	if err != nil {
+		return nil, false, err
+	}
+	req, err := client.NewRequest(http.MethodPost, searchPath+typ, nil, payload)
 	if err != nil {
-		panic(err)
+		return nil, false, err
 	}
+	body, err := client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("error searching Wavefront %s, %s", typ, err)
+	}
+	defer body.Close()
 
-	return searchResponse.Response.Items
+	var resp searchResponse
+	if err := json.NewDecoder(body).Decode(&resp); err != nil {
+		return nil, false, fmt.Errorf("error parsing Wavefront %s search results, %s", typ, err)
+	}
+	return resp.Response.Items, resp.Response.MoreItems, nil
 }
@@ -0,0 +1,146 @@
+package wavefront
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/WavefrontHQ/go-wavefront-management-api/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSearchServer serves a search API holding total alerts and records the
+// parameters of each search it receives.
+func testSearchServer(t *testing.T, total int) (*httptest.Server, *[]searchRequest) {
+	var searches []searchRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var params searchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&params))
+		searches = append(searches, params)
+
+		items := make([]map[string]string, 0)
+		for i := params.Offset; i < params.Offset+params.Limit && i < total; i++ {
+			items = append(items, map[string]string{"id": fmt.Sprint(i)})
+		}
+		response := map[string]interface{}{
+			"status": map[string]int{"code": 200},
+			"response": map[string]interface{}{
+				"items":     items,
+				"moreItems": params.Offset+params.Limit < total,
+			},
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(response))
+	}))
+	t.Cleanup(server.Close)
+	return server, &searches
+}
+
+func testSearchClient(t *testing.T, server *httptest.Server) *wavefrontClient {
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL, Token: "secret"})
+	require.NoError(t, err)
+	return &wavefrontClient{client: *client}
+}
+
+func searchIDs(t *testing.T, items json.RawMessage) []string {
+	var alerts []*wavefront.Alert
+	require.NoError(t, json.Unmarshal(items, &alerts))
+	ids := make([]string, len(alerts))
+	for i, alert := range alerts {
+		ids[i] = *alert.ID
+	}
+	return ids
+}
+
+func TestSearchAll_FetchesEveryPage(t *testing.T) {
+	server, searches := testSearchServer(t, 2500)
+
+	items, err := searchAll(context.Background(), 0, 0, "alert", nil, nil, testSearchClient(t, server))
+	require.NoError(t, err)
+	ids := searchIDs(t, items)
+	assert.Len(t, ids, 2500)
+	assert.Equal(t, "2499", ids[2499])
+	require.Len(t, *searches, 3)
+	assert.Equal(t, 2000, (*searches)[2].Offset)
+}
+
+func TestSearchAll_StopsAtLimit(t *testing.T) {
+	server, searches := testSearchServer(t, 2500)
+
+	items, err := searchAll(context.Background(), 1200, 100, "alert", nil, nil, testSearchClient(t, server))
+	require.NoError(t, err)
+	ids := searchIDs(t, items)
+	assert.Len(t, ids, 1200)
+	assert.Equal(t, "100", ids[0])
+	assert.Equal(t, "1299", ids[1199])
+	require.Len(t, *searches, 2)
+	assert.Equal(t, 200, (*searches)[1].Limit)
+}
+
+func TestSearchAll_NoResults(t *testing.T) {
+	server, _ := testSearchServer(t, 0)
+
+	items, err := searchAll(context.Background(), 0, 0, "alert", nil, nil, testSearchClient(t, server))
+	require.NoError(t, err)
+	assert.Empty(t, searchIDs(t, items))
+}
+
+func TestSearchAll_ReturnsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := searchAll(context.Background(), 0, 0, "alert", nil, nil, testSearchClient(t, server))
+	assert.ErrorContains(t, err, "error searching Wavefront alert")
+}
+
+func TestSearchAll_KeepsAddressPath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		fmt.Fprint(w, `{"status":{"code":200},"response":{"items":[],"moreItems":false}}`)
+	}))
+	defer server.Close()
+
+	client, err := wavefront.NewClient(&wavefront.Config{Address: server.URL + "/wavefront", Token: "secret"})
+	require.NoError(t, err)
+	_, err = searchAll(context.Background(), 0, 0, "alert", nil, nil, &wavefrontClient{client: *client})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/wavefront/api/v2/search/alert"}, paths)
+}
+
+func TestSearchAll_SendsFilters(t *testing.T) {
+	server, searches := testSearchServer(t, 1)
+
+	d := schema.TestResourceDataRaw(t, dataSourceAlertsSchema(), map[string]interface{}{
+		filterKey: []interface{}{
+			map[string]interface{}{
+				filterKeyKey:   "tags",
+				filterValueKey: "team.payments",
+			},
+			map[string]interface{}{
+				filterKeyKey:      "status",
+				filterValueKey:    "SNOOZED",
+				matchingMethodKey: "EXACT",
+				negatedKey:        true,
+			},
+		},
+	})
+	_, err := searchAll(context.Background(), 0, 0, "alert", nil, expandSearchFilters(d), testSearchClient(t, server))
+	require.NoError(t, err)
+	require.Len(t, *searches, 1)
+	assert.Equal(t, []*searchCondition{
+		{Key: "tags", Value: "team.payments", MatchingMethod: "CONTAINS"},
+		{Key: "status", Value: "SNOOZED", MatchingMethod: "EXACT", Negated: true},
+	}, (*searches)[0].Conditions)
+}
+
+func TestExpandSearchFilters_Empty(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, dataSourceUsersSchema(), map[string]interface{}{})
+	assert.Empty(t, expandSearchFilters(d))
+}
@@ -0,0 +1,189 @@
+package wql
+
+import (
+	"sort"
+	"strings"
+)
+
+// functions are the Wavefront Query Language functions, with the minimum
+// number of arguments they take. Function names aren't case sensitive.
+var functions = map[string]int{
+	// Data
+	"ts": 1, "hs": 1, "spans": 1, "traces": 1, "events": 1,
+
+	// Aggregation
+	"sum": 1, "avg": 1, "min": 1, "max": 1, "count": 1, "median": 1, "variance": 1, "stddev": 1,
+	"percentile": 2, "rawsum": 1, "rawavg": 1, "rawmin": 1, "rawmax": 1, "rawcount": 1,
+	"rawmedian": 1, "rawvariance": 1, "rawstddev": 1, "rawpercentile": 2,
+
+	// Moving window and time
+	"mavg": 2, "msum": 2, "mmin": 2, "mmax": 2, "mcount": 2, "mmedian": 2, "mvar": 2,
+	"mpercentile": 3, "mcorr": 3, "mseriescount": 2, "flapping": 2, "any": 2, "all": 2,
+	"integrate": 2, "integral": 1, "first": 1, "last": 1, "lag": 2, "since": 1, "align": 2,
+	"at": 2, "timeOffset": 2, "hideAfter": 2, "hideBefore": 2, "bestEffort": 1, "globalFirst": 1,
+	"globalLast": 1,
+
+	// Rates and differences
+	"rate": 1, "deriv": 1, "derivative": 1, "rateDiff": 1, "diff": 1, "delta": 1,
+
+	// Filtering and ranking
+	"highest": 1, "lowest": 1, "top": 1, "bottom": 1, "topk": 2, "bottomk": 2, "limit": 2,
+	"filter": 1, "retainSeries": 2, "removeSeries": 2, "removeSource": 1, "removeTag": 2,
+	"removeTagKey": 2, "sample": 2, "between": 3, "nonzero": 1, "exists": 1, "collect": 1,
+
+	// Missing data
+	"default": 1, "next": 1, "interpolate": 1, "fill": 1,
+
+	// Conditional
+	"if": 2,
+
+	// Mathematical
+	"abs": 1, "sqrt": 1, "exp": 1, "log": 1, "log10": 1, "log2": 1, "pow": 2, "ceil": 1,
+	"floor": 1, "round": 1, "sign": 1, "sin": 1, "cos": 1, "tan": 1, "asin": 1, "acos": 1,
+	"atan": 1, "atan2": 2, "sinh": 1, "cosh": 1, "tanh": 1, "toDegrees": 1, "toRadians": 1,
+	"random": 0, "normalize": 1, "haversine": 4, "mod": 2,
+
+	// Metadata
+	"aliasMetric": 2, "aliasSource": 2, "aliasTag": 2, "taggify": 3,
+
+	// Date and time
+	"time": 0, "now": 0, "year": 0, "month": 0, "dayOfYear": 0, "day": 0, "weekday": 0,
+	"hour": 0, "minute": 0, "second": 0, "isToday": 0, "dayOfMonth": 0, "dayOfWeek": 0,
+
+	// Histograms
+	"merge": 1, "cumulativeHistogram": 1, "cumulativePercentile": 2, "frequencyHistogram": 1,
+	"alignedSummary": 2, "summary": 1, "histogram": 1, "sampleHistogram": 1, "histogramSum": 1,
+
+	// Predictive
+	"hw": 3, "nnforecast": 2, "anomalous": 3, "linearRegression": 1,
+
+	// Events
+	"ongoing": 1, "closed": 1, "around": 2, "within": 2,
+
+	// Traces and spans
+	"highpass": 2, "lowpass": 2, "rootSpans": 1, "leafSpans": 1, "childOf": 2, "parentOf": 2,
+	"descendantOf": 2, "ancestorOf": 2, "spanSummary": 1,
+
+	// Join
+	"join": 0,
+}
+
+// functionNames maps the lowercased names of functions to their names.
+var functionNames = make(map[string]string, len(functions))
+
+func init() {
+	for name := range functions {
+		functionNames[strings.ToLower(name)] = name
+	}
+}
+
+// Validate parses query, returning a syntax error or the warnings about its
+// function calls: calls of functions which don't exist, with the closest
+// function name suggested, and calls with too few arguments.
+func Validate(query string) ([]*Error, error) {
+	node, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []*Error
+	walk(node, func(n Node) {
+		call, ok := n.(*Call)
+		if !ok {
+			return
+		}
+		name, known := functionNames[strings.ToLower(call.Name)]
+		minArgs := functions[name]
+		switch {
+		case !known:
+			message := "unknown function " + call.Name
+			if suggestion := closestFunction(call.Name); suggestion != "" {
+				message += ", did you mean " + suggestion + "?"
+			}
+			warnings = append(warnings, newError(query, call.pos, "%s", message))
+		case len(call.Args) < minArgs:
+			warnings = append(warnings, newError(query, call.pos, "%s takes at least %d argument%s, got %d",
+				call.Name, minArgs, plural(minArgs), len(call.Args)))
+		}
+	})
+	return warnings, nil
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
+
+// walk calls visit for node and its descendants, depth first.
+func walk(node Node, visit func(Node)) {
+	visit(node)
+	switch n := node.(type) {
+	case *Call:
+		for _, arg := range n.Args {
+			walk(arg, visit)
+		}
+	case *Binary:
+		walk(n.Left, visit)
+		walk(n.Right, visit)
+	case *Unary:
+		walk(n.X, visit)
+	case *Paren:
+		walk(n.X, visit)
+	}
+}
+
+// closestFunction returns the function at an edit distance of at most 2 of
+// name, or "" if there's none.
+func closestFunction(name string) string {
+	name = strings.ToLower(name)
+	lowered := make([]string, 0, len(functionNames))
+	for f := range functionNames {
+		lowered = append(lowered, f)
+	}
+	sort.Strings(lowered)
+	best, bestDistance := "", 3
+	for _, f := range lowered {
+		if d := editDistance(name, f); d < bestDistance {
+			best, bestDistance = functionNames[f], d
+		}
+	}
+	return best
+}
+
+// editDistance returns the optimal string alignment distance between a and
+// b: the Levenshtein distance, counting transpositions of adjacent
+// characters as one edit, as in mvag for mavg.
+func editDistance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,248 @@
+package wql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokVar
+	tokLParen
+	tokRParen
+	tokComma
+	tokOperator
+)
+
+// token is a lexical token of a query, at byte offset pos.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of query"
+	case tokString:
+		return "string " + t.text
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// numberPattern matches numbers, with an optional exponent and SI or
+// duration suffix, such as 10, 0.5, 1e-3, 2k or 5m.
+var numberPattern = regexp.MustCompile(`^(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?[a-zA-Z]*$`)
+
+// lexer splits a query into tokens. Metric names, point tags and their
+// values are lexed as identifiers, which may contain wildcards, dots and
+// dashes: ts(cpu.*, env=us-west-2).
+type lexer struct {
+	query  string
+	pos    int
+	tokens []token
+}
+
+func lex(query string) ([]token, error) {
+	l := &lexer{query: query}
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		l.tokens = append(l.tokens, t)
+		if t.kind == tokEOF {
+			return l.tokens, nil
+		}
+	}
+}
+
+// operandExpected returns whether the next token starts an operand, rather
+// than an operator, deciding whether * starts a wildcard or multiplies.
+func (l *lexer) operandExpected() bool {
+	if len(l.tokens) == 0 {
+		return true
+	}
+	switch last := l.tokens[len(l.tokens)-1]; last.kind {
+	case tokLParen, tokComma, tokOperator:
+		return true
+	case tokIdent:
+		return isKeyword(last.text)
+	}
+	return false
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.query) {
+		return 0
+	}
+	r, _ := utf8.DecodeRuneInString(l.query[l.pos+offset:])
+	return r
+}
+
+func isIdentRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.*~?:", r)
+}
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.query) && unicode.IsSpace(l.peek(0)) {
+		_, size := utf8.DecodeRuneInString(l.query[l.pos:])
+		l.pos += size
+	}
+	start := l.pos
+	if l.pos >= len(l.query) {
+		return token{kind: tokEOF, pos: start}, nil
+	}
+
+	r := l.peek(0)
+	switch {
+	case r == '(':
+		l.pos++
+		return token{kind: tokLParen, text: "(", pos: start}, nil
+	case r == ')':
+		l.pos++
+		return token{kind: tokRParen, text: ")", pos: start}, nil
+	case r == ',':
+		l.pos++
+		return token{kind: tokComma, text: ",", pos: start}, nil
+	case r == '"' || r == '\'':
+		return l.lexString(r)
+	case r == '$':
+		return l.lexVar()
+	case unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(l.peek(1))):
+		return l.lexNumber(), nil
+	case (r == '*' || r == '~') && l.operandExpected():
+		return l.lexIdent()
+	case unicode.IsLetter(r) || r == '_' || r == '~':
+		return l.lexIdent()
+	}
+
+	for _, op := range []string{">=", "<=", "!=", "==", "+", "-", "*", "/", "%", ">", "<", "="} {
+		if strings.HasPrefix(l.query[l.pos:], op) {
+			l.pos += len(op)
+			return token{kind: tokOperator, text: op, pos: start}, nil
+		}
+	}
+	return token{}, newError(l.query, start, "unexpected character %q", r)
+}
+
+// lexIdent lexes an identifier, in which a dash joins the characters around
+// it: us-west-2 is an identifier, while a - b is a subtraction.
+func (l *lexer) lexIdent() (token, error) {
+	return l.lexIdentFrom(l.pos)
+}
+
+// scanIdent consumes the rest of an identifier, including the variables in
+// it, as in ${prefix}.requests or cpu.${suffix}.
+func (l *lexer) scanIdent() error {
+	for l.pos < len(l.query) {
+		r := l.peek(0)
+		switch {
+		case r == '$' && l.peek(1) == '{':
+			if err := l.scanVar(); err != nil {
+				return err
+			}
+		case isIdentRune(r) || (r == '-' && (isIdentRune(l.peek(1)) || l.peek(1) == '$')):
+			l.pos += utf8.RuneLen(r)
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// lexNumber lexes a number. A run of characters starting with a digit that
+// isn't a number, such as the address 10.0.0.1, is an identifier.
+func (l *lexer) lexNumber() token {
+	start := l.pos
+	for l.pos < len(l.query) {
+		r := l.peek(0)
+		exponentSign := (r == '-' || r == '+') && l.pos > start &&
+			strings.ContainsRune("eE", rune(l.query[l.pos-1])) && unicode.IsDigit(l.peek(1))
+		if isIdentRune(r) || exponentSign {
+			l.pos += utf8.RuneLen(r)
+			continue
+		}
+		break
+	}
+	text := l.query[start:l.pos]
+	if numberPattern.MatchString(text) {
+		return token{kind: tokNumber, text: text, pos: start}
+	}
+	return token{kind: tokIdent, text: text, pos: start}
+}
+
+func (l *lexer) lexString(quote rune) (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.query) {
+		r, size := utf8.DecodeRuneInString(l.query[l.pos:])
+		l.pos += size
+		switch r {
+		case '\\':
+			if l.pos < len(l.query) {
+				_, size = utf8.DecodeRuneInString(l.query[l.pos:])
+				l.pos += size
+			}
+		case quote:
+			return token{kind: tokString, text: l.query[start:l.pos], pos: start}, nil
+		}
+	}
+	return token{}, newError(l.query, start, "unterminated string")
+}
+
+// lexVar lexes a dashboard variable or a reference to another query of a
+// chart, ${name}. A variable followed by the characters of an identifier,
+// other than a wildcard, is part of that identifier: ${prefix}.requests.
+func (l *lexer) lexVar() (token, error) {
+	start := l.pos
+	if err := l.scanVar(); err != nil {
+		return token{}, err
+	}
+	if r := l.peek(0); r != '*' && (isIdentRune(r) || (r == '-' && isIdentRune(l.peek(1)))) {
+		return l.lexIdentFrom(start)
+	}
+	return token{kind: tokVar, text: l.query[start:l.pos], pos: start}, nil
+}
+
+func (l *lexer) lexIdentFrom(start int) (token, error) {
+	if err := l.scanIdent(); err != nil {
+		return token{}, err
+	}
+	return token{kind: tokIdent, text: l.query[start:l.pos], pos: start}, nil
+}
+
+// scanVar consumes the variable at the current position.
+func (l *lexer) scanVar() error {
+	start := l.pos
+	if l.peek(1) != '{' {
+		return newError(l.query, start, "expected { after $")
+	}
+	end := strings.IndexByte(l.query[l.pos:], '}')
+	if end < 0 {
+		return newError(l.query, start, "unterminated variable, expected }")
+	}
+	if strings.TrimSpace(l.query[l.pos+2:l.pos+end]) == "" {
+		return newError(l.query, start, "empty variable name")
+	}
+	l.pos += end + 1
+	return nil
+}
+
+func isKeyword(text string) bool {
+	switch strings.ToLower(text) {
+	case "and", "or", "not":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,376 @@
+// Package wql parses queries of the Wavefront Query Language, to find syntax
+// errors in queries before they're sent to Wavefront.
+//
+// The grammar covers data queries (ts, hs, spans, traces and events) with
+// their filters, function calls, aggregations and their group by arguments,
+// arithmetic, comparison and boolean operators, and ${} variables. Which
+// functions exist and how many arguments they take are checked separately,
+// by Validate, so that functions added to Wavefront aren't syntax errors.
+package wql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Error is an error at a position of a query. Line and Column start at 1,
+// and Column counts characters rather than bytes.
+type Error struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 1 {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+// newError returns an Error at the byte offset pos of query.
+func newError(query string, pos int, format string, a ...interface{}) *Error {
+	line := 1 + strings.Count(query[:pos], "\n")
+	lineStart := strings.LastIndexByte(query[:pos], '\n') + 1
+	return &Error{
+		Line:    line,
+		Column:  1 + utf8.RuneCountInString(query[lineStart:pos]),
+		Message: fmt.Sprintf(format, a...),
+	}
+}
+
+// Node is a node of the syntax tree of a query.
+type Node interface {
+	// Pos returns the byte offset of the node in the query.
+	Pos() int
+}
+
+// Call is a function call, such as ts(cpu.usage) or mavg(5m, ts(cpu.usage)).
+type Call struct {
+	Name string
+	Args []Node
+	pos  int
+}
+
+// Binary is a binary operation. Op is the operator as written, lowercased
+// for and and or.
+type Binary struct {
+	Op          string
+	Left, Right Node
+	pos         int
+}
+
+// Unary is a unary - or not.
+type Unary struct {
+	Op  string
+	X   Node
+	pos int
+}
+
+// Ident is a metric, source, point tag or tag value name, possibly with
+// wildcards, or an argument of a function such as a group by tag.
+type Ident struct {
+	Name string
+	pos  int
+}
+
+// Number is a number, possibly with an SI or duration suffix.
+type Number struct {
+	Text string
+	pos  int
+}
+
+// String is a quoted string, including its quotes.
+type String struct {
+	Text string
+	pos  int
+}
+
+// Var is a ${} variable.
+type Var struct {
+	Text string
+	pos  int
+}
+
+// Paren is a parenthesized expression.
+type Paren struct {
+	X   Node
+	pos int
+}
+
+// Pos implements Node.
+func (n *Call) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Binary) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Unary) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Ident) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Number) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *String) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Var) Pos() int { return n.pos }
+
+// Pos implements Node.
+func (n *Paren) Pos() int { return n.pos }
+
+type parser struct {
+	query  string
+	tokens []token
+	i      int
+}
+
+// Parse parses query, returning an *Error for syntax errors.
+func Parse(query string) (Node, error) {
+	tokens, err := lex(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{query: query, tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, newError(query, p.peek().pos, "empty query")
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.unexpected(t, "an operator")
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+func (p *parser) unexpected(t token, expected string) *Error {
+	if t.kind == tokRParen {
+		return newError(p.query, t.pos, "unexpected ), expected %s", expected)
+	}
+	return newError(p.query, t.pos, "unexpected %s, expected %s", t, expected)
+}
+
+// keyword returns whether t is the keyword word, which isn't case sensitive.
+func keyword(t token, word string) bool {
+	return t.kind == tokIdent && strings.EqualFold(t.text, word)
+}
+
+// parseBinary parses a left associative sequence of the operands parsed by
+// next, separated by the operators op returns.
+func (p *parser) parseBinary(next func() (Node, error), op func(token) (string, bool)) (Node, error) {
+	left, err := next()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		name, ok := op(p.peek())
+		if !ok {
+			return left, nil
+		}
+		pos := p.advance().pos
+		var right Node
+		if right, err = next(); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: name, Left: left, Right: right, pos: pos}
+	}
+}
+
+// keywordOperator returns an operator func matching the keyword word.
+func keywordOperator(word string) func(token) (string, bool) {
+	return func(t token) (string, bool) {
+		return word, keyword(t, word)
+	}
+}
+
+// symbolOperator returns an operator func matching the operators ops.
+func symbolOperator(ops ...string) func(token) (string, bool) {
+	return func(t token) (string, bool) {
+		if t.kind != tokOperator {
+			return "", false
+		}
+		for _, op := range ops {
+			if t.text == op {
+				return op, true
+			}
+		}
+		return "", false
+	}
+}
+
+var comparisonOperator = symbolOperator("=", "==", "!=", ">", "<", ">=", "<=")
+
+func (p *parser) parseOr() (Node, error) {
+	return p.parseBinary(p.parseAnd, keywordOperator("or"))
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	return p.parseBinary(p.parseNot, keywordOperator("and"))
+}
+
+func (p *parser) parseNot() (Node, error) {
+	if keyword(p.peek(), "not") {
+		op := p.advance()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: "not", X: x, pos: op.pos}, nil
+	}
+	return p.parseComparison()
+}
+
+// parseComparison parses a comparison, which unlike the other operators
+// can't be chained: a < b < c is a syntax error.
+func (p *parser) parseComparison() (Node, error) {
+	left, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if op, ok := comparisonOperator(p.peek()); ok {
+		pos := p.advance().pos
+		var right Node
+		if right, err = p.parseAdditive(); err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right, pos: pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAdditive() (Node, error) {
+	return p.parseBinary(p.parseMultiplicative, symbolOperator("+", "-"))
+}
+
+func (p *parser) parseMultiplicative() (Node, error) {
+	return p.parseBinary(p.parseUnary, symbolOperator("*", "/", "%"))
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if _, ok := symbolOperator("-", "+")(p.peek()); ok {
+		op := p.advance()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: op.text, X: x, pos: op.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.advance()
+	switch t.kind {
+	case tokNumber:
+		return &Number{Text: t.text, pos: t.pos}, nil
+	case tokString:
+		return &String{Text: t.text, pos: t.pos}, nil
+	case tokVar:
+		return &Var{Text: t.text, pos: t.pos}, nil
+	case tokLParen:
+		x, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err = p.expectClose(t); err != nil {
+			return nil, err
+		}
+		return &Paren{X: x, pos: t.pos}, nil
+	case tokIdent:
+		if isKeyword(t.text) {
+			return nil, p.unexpected(t, "an expression")
+		}
+		if p.peek().kind == tokLParen {
+			return p.parseCall(t)
+		}
+		return &Ident{Name: t.text, pos: t.pos}, nil
+	case tokEOF:
+		return nil, newError(p.query, t.pos, "unexpected end of query, expected an expression")
+	}
+	return nil, p.unexpected(t, "an expression")
+}
+
+// expectClose consumes the ) closing the ( open.
+func (p *parser) expectClose(open token) error {
+	t := p.peek()
+	if t.kind == tokRParen {
+		p.advance()
+		return nil
+	}
+	if t.kind == tokEOF {
+		return newError(p.query, t.pos, "unexpected end of query, expected ) to close %s", p.describeOpen(open))
+	}
+	return p.unexpected(t, ", or ) to close "+p.describeOpen(open))
+}
+
+func (p *parser) describeOpen(open token) string {
+	err := newError(p.query, open.pos, "")
+	if err.Line > 1 {
+		return fmt.Sprintf("( at line %d, column %d", err.Line, err.Column)
+	}
+	return fmt.Sprintf("( at column %d", err.Column)
+}
+
+func (p *parser) parseCall(name token) (Node, error) {
+	open := p.advance()
+	call := &Call{Name: name.text, pos: name.pos}
+	if strings.EqualFold(name.text, "join") {
+		// The arguments of join are SQL like: join(ts(a) AS a INNER JOIN
+		// ts(b) AS b ON a.env = b.env, a + b). Only their parentheses are
+		// checked.
+		return call, p.skipBalanced(open)
+	}
+	if p.peek().kind == tokRParen {
+		p.advance()
+		return call, nil
+	}
+	for {
+		arg, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		call.Args = append(call.Args, arg)
+		if p.peek().kind != tokComma {
+			break
+		}
+		p.advance()
+	}
+	return call, p.expectClose(open)
+}
+
+// skipBalanced consumes the tokens up to the ) closing the ( open.
+func (p *parser) skipBalanced(open token) error {
+	depth := 1
+	for {
+		t := p.advance()
+		switch t.kind {
+		case tokLParen:
+			depth++
+		case tokRParen:
+			depth--
+			if depth == 0 {
+				return nil
+			}
+		case tokEOF:
+			return newError(p.query, t.pos, "unexpected end of query, expected ) to close %s", p.describeOpen(open))
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package wql
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readCorpus reads the queries of a corpus file: one per line, skipping
+// blank lines and # comments, with a trailing \ continuing a query on the
+// next line.
+func readCorpus(t *testing.T, name string) []string {
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var queries []string
+	var continued []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(continued) == 0 && (strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+		if strings.HasSuffix(line, `\`) {
+			continued = append(continued, strings.TrimSuffix(line, `\`))
+			continue
+		}
+		queries = append(queries, strings.Join(append(continued, line), "\n"))
+		continued = nil
+	}
+	require.NoError(t, scanner.Err())
+	require.Empty(t, continued, "the last query of %s must not be continued", name)
+	return queries
+}
+
+func TestParse_ValidCorpus(t *testing.T) {
+	queries := readCorpus(t, "testdata/valid.wql")
+	require.NotEmpty(t, queries)
+	for _, query := range queries {
+		_, err := Parse(query)
+		assert.NoError(t, err, query)
+
+		warnings, err := Validate(query)
+		assert.NoError(t, err, query)
+		assert.Empty(t, warnings, query)
+	}
+}
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	cases := []struct {
+		query   string
+		line    int
+		column  int
+		message string
+	}{
+		{"", 1, 1, "empty query"},
+		{"   ", 1, 4, "empty query"},
+		{"ts(cpu.usage", 1, 13, "unexpected end of query, expected ) to close ( at column 3"},
+		{"ts(cpu.usage))", 1, 14, `unexpected ), expected an operator`},
+		{"ts(cpu.usage) >", 1, 16, "unexpected end of query, expected an expression"},
+		{"ts(cpu.usage) > > 1", 1, 17, `unexpected ">", expected an expression`},
+		{"ts(cpu.usage) 90", 1, 15, `unexpected "90", expected an operator`},
+		{"ts(cpu.usage,)", 1, 14, "unexpected ), expected an expression"},
+		{"ts(,cpu.usage)", 1, 4, `unexpected ",", expected an expression`},
+		{"sum(ts(a) ts(b))", 1, 11, `unexpected "ts", expected , or ) to close ( at column 4`},
+		{"ts(cpu.usage, source=\"web)", 1, 22, "unterminated string"},
+		{"ts(cpu.usage) > 90 and", 1, 23, "unexpected end of query, expected an expression"},
+		{"ts(a) and or ts(b)", 1, 11, `unexpected "or", expected an expression`},
+		{"ts(a) < ts(b) < ts(c)", 1, 15, `unexpected "<", expected an operator`},
+		{"ts(cpu.usage) # comment", 1, 15, `unexpected character '#'`},
+		{"ts(cpu.usage, env=$env)", 1, 19, "expected { after $"},
+		{"ts(cpu.usage, env=${env)", 1, 19, "unterminated variable, expected }"},
+		{"ts(cpu.usage, env=${ })", 1, 19, "empty variable name"},
+		{"()", 1, 2, "unexpected ), expected an expression"},
+		{"join(ts(a) AS a INNER JOIN ts(b) AS b ON a.x = b.x, a / b", 1, 58, "expected ) to close ( at column 5"},
+		{"mavg(5m, ts(cpu.usage)\n  / ts(cpu.count", 2, 17, "expected ) to close ( at line 2, column 7"},
+		{"sum(ts(requests))\n  / sum(ts(errors)))", 2, 20, `unexpected ), expected an operator`},
+		{"ts(\"métrique\") >> 1", 1, 17, `unexpected ">", expected an expression`},
+	}
+	for _, c := range cases {
+		_, err := Parse(c.query)
+		var syntaxErr *Error
+		if !assert.ErrorAs(t, err, &syntaxErr, c.query) {
+			continue
+		}
+		assert.Equal(t, c.line, syntaxErr.Line, c.query)
+		assert.Equal(t, c.column, syntaxErr.Column, c.query)
+		assert.Contains(t, syntaxErr.Message, c.message, c.query)
+	}
+}
+
+func TestParse_Tree(t *testing.T) {
+	node, err := Parse("sum(ts(cpu.usage, env=prod), sources) > 90 or not ts(up)")
+	require.NoError(t, err)
+
+	or, ok := node.(*Binary)
+	require.True(t, ok)
+	assert.Equal(t, "or", or.Op)
+	assert.Equal(t, 43, or.Pos())
+
+	comparison := or.Left.(*Binary)
+	assert.Equal(t, ">", comparison.Op)
+	assert.Equal(t, &Number{Text: "90", pos: 40}, comparison.Right)
+
+	sum := comparison.Left.(*Call)
+	assert.Equal(t, "sum", sum.Name)
+	require.Len(t, sum.Args, 2)
+	assert.Equal(t, &Ident{Name: "sources", pos: 29}, sum.Args[1])
+
+	ts := sum.Args[0].(*Call)
+	require.Len(t, ts.Args, 2)
+	assert.Equal(t, &Ident{Name: "cpu.usage", pos: 7}, ts.Args[0])
+	filter := ts.Args[1].(*Binary)
+	assert.Equal(t, "=", filter.Op)
+	assert.Equal(t, &Ident{Name: "prod", pos: 22}, filter.Right)
+
+	not := or.Right.(*Unary)
+	assert.Equal(t, "not", not.Op)
+	assert.Equal(t, "ts", not.X.(*Call).Name)
+}
+
+func TestParse_Precedence(t *testing.T) {
+	node, err := Parse("1 + 2 * 3 > 4 and 5 < 6")
+	require.NoError(t, err)
+
+	and := node.(*Binary)
+	assert.Equal(t, "and", and.Op)
+	greater := and.Left.(*Binary)
+	assert.Equal(t, ">", greater.Op)
+	plus := greater.Left.(*Binary)
+	assert.Equal(t, "+", plus.Op)
+	assert.Equal(t, "*", plus.Right.(*Binary).Op)
+}
+
+func TestValidate_Warnings(t *testing.T) {
+	cases := []struct {
+		query    string
+		warnings []string
+	}{
+		{"ts(cpu.usage)", nil},
+		{"MAVG(5m, ts(cpu.usage))", nil},
+		{"mvag(5m, ts(cpu.usage))", []string{"column 1: unknown function mvag, did you mean mavg?"}},
+		{"aliasmetrc(ts(cpu), \"x\")", []string{"column 1: unknown function aliasmetrc, did you mean aliasMetric?"}},
+		{"frobnicate(ts(cpu.usage))", []string{"column 1: unknown function frobnicate"}},
+		{"sum(mavg(ts(cpu.usage)))", []string{"column 5: mavg takes at least 2 arguments, got 1"}},
+		{"ts()", []string{"column 1: ts takes at least 1 argument, got 0"}},
+		{"sum(ts(a))\n/ smu(ts(b))", []string{"line 2, column 3: unknown function smu, did you mean sum?"}},
+	}
+	for _, c := range cases {
+		warnings, err := Validate(c.query)
+		require.NoError(t, err, c.query)
+		var messages []string
+		for _, w := range warnings {
+			messages = append(messages, w.Error())
+		}
+		assert.Equal(t, c.warnings, messages, c.query)
+	}
+
+	_, err := Validate("ts(")
+	assert.Error(t, err)
+}